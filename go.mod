@@ -1,11 +1,12 @@
 module github.com/kilip/go-console
 
-go 1.17
+go 1.18
 
 require (
 	github.com/frankban/quicktest v1.13.1
 	github.com/kilip/go-wordwrap v0.1.0
 	github.com/mattn/go-isatty v0.0.14
+	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c
 )
 
 require (
@@ -13,6 +14,5 @@ require (
 	github.com/kr/pretty v0.3.0 // indirect
 	github.com/kr/text v0.2.0 // indirect
 	github.com/rogpeppe/go-internal v1.6.1 // indirect
-	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
 	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
 )
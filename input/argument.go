@@ -0,0 +1,90 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Modes of an InputArgument, ArgumentIsArray can be combined with ArgumentRequired or ArgumentOptional
+const (
+	ArgumentRequired = 1 << iota
+	ArgumentOptional
+	ArgumentIsArray
+)
+
+// InputArgument represents a command line argument
+type InputArgument struct {
+	name         string
+	mode         int
+	description  string
+	defaultValue interface{}
+}
+
+// NewInputArgument creates new InputArgument object.
+// Arguments are optional unless the mode has ArgumentRequired,
+// the default value of an array argument must be a []string.
+func NewInputArgument(name string, mode int, description string, defaultValue interface{}) (*InputArgument, error) {
+	if "" == name {
+		return nil, errors.New("An argument name cannot be empty.")
+	}
+	if mode < 0 || mode > (ArgumentRequired|ArgumentOptional|ArgumentIsArray) || mode&ArgumentRequired != 0 && mode&ArgumentOptional != 0 {
+		return nil, fmt.Errorf(`Argument mode "%d" is not valid.`, mode)
+	}
+	if 0 == mode&ArgumentRequired {
+		mode |= ArgumentOptional
+	}
+
+	argument := &InputArgument{
+		name:        name,
+		mode:        mode,
+		description: description,
+	}
+	if err := argument.SetDefault(defaultValue); nil != err {
+		return nil, err
+	}
+
+	return argument, nil
+}
+
+// GetName returns the argument name
+func (a *InputArgument) GetName() string {
+	return a.name
+}
+
+// IsRequired returns whether the argument is required
+func (a *InputArgument) IsRequired() bool {
+	return a.mode&ArgumentRequired == ArgumentRequired
+}
+
+// IsArray returns whether the argument takes multiple values
+func (a *InputArgument) IsArray() bool {
+	return a.mode&ArgumentIsArray == ArgumentIsArray
+}
+
+// GetDescription returns the argument description
+func (a *InputArgument) GetDescription() string {
+	return a.description
+}
+
+// GetDefault returns the default value, an empty []string for array arguments without default
+func (a *InputArgument) GetDefault() interface{} {
+	return a.defaultValue
+}
+
+// SetDefault sets the default value of an optional argument
+func (a *InputArgument) SetDefault(value interface{}) error {
+	if a.IsRequired() && nil != value {
+		return errors.New("Cannot set a default value except for ArgumentOptional mode.")
+	}
+
+	if a.IsArray() {
+		if nil == value {
+			value = []string{}
+		} else if _, ok := value.([]string); !ok {
+			return errors.New("A default value for an array argument must be an array.")
+		}
+	}
+
+	a.defaultValue = value
+	return nil
+}
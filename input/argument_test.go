@@ -0,0 +1,58 @@
+package input
+
+import (
+	qt "github.com/frankban/quicktest"
+	"testing"
+)
+
+func TestNewInputArgument(t *testing.T) {
+	c := qt.New(t)
+
+	argument, err := NewInputArgument("name", 0, "The name", "John")
+	c.Assert(err, qt.IsNil)
+	c.Assert(argument.GetName(), qt.Equals, "name")
+	c.Assert(argument.GetDescription(), qt.Equals, "The name")
+	c.Assert(argument.GetDefault(), qt.Equals, "John")
+	c.Assert(argument.IsRequired(), qt.IsFalse)
+	c.Assert(argument.IsArray(), qt.IsFalse)
+
+	argument, err = NewInputArgument("name", ArgumentRequired, "", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(argument.IsRequired(), qt.IsTrue)
+	c.Assert(argument.GetDefault(), qt.IsNil)
+
+	argument, err = NewInputArgument("files", ArgumentOptional|ArgumentIsArray, "", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(argument.IsArray(), qt.IsTrue)
+	c.Assert(argument.GetDefault(), qt.DeepEquals, []string{})
+
+	argument, err = NewInputArgument("files", ArgumentIsArray, "", []string{"a.txt"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(argument.IsRequired(), qt.IsFalse)
+	c.Assert(argument.GetDefault(), qt.DeepEquals, []string{"a.txt"})
+}
+
+func TestNewInputArgument_Errors(t *testing.T) {
+	type cs struct {
+		Name         string
+		ArgName      string
+		Mode         int
+		DefaultValue interface{}
+		Error        string
+	}
+	cases := []cs{
+		{Name: "empty name", Error: "An argument name cannot be empty."},
+		{Name: "unknown mode", ArgName: "name", Mode: 8, Error: `Argument mode "8" is not valid.`},
+		{Name: "required and optional", ArgName: "name", Mode: ArgumentRequired | ArgumentOptional, Error: `Argument mode "3" is not valid.`},
+		{Name: "default of required argument", ArgName: "name", Mode: ArgumentRequired, DefaultValue: "John", Error: "Cannot set a default value except for ArgumentOptional mode."},
+		{Name: "default of array argument", ArgName: "files", Mode: ArgumentIsArray, DefaultValue: "a.txt", Error: "A default value for an array argument must be an array."},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := NewInputArgument(testCase.ArgName, testCase.Mode, "", testCase.DefaultValue)
+			c.Assert(err, qt.ErrorMatches, testCase.Error)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package input
+
+import (
+	"os"
+	"strings"
+)
+
+// ArgvInput represents an input coming from the command line.
+// It supports GNU style long options (--name=value and --name value), negated options (--no-name),
+// short options (-n value and -nvalue), short option clusters (-abc), repeated array options
+// and the "--" marker ending the options.
+type ArgvInput struct {
+	tokens []string
+	parsed []string
+	*Input
+}
+
+// NewArgvInput creates new ArgvInput object from argv, the first element being the program name.
+// A nil argv means os.Args.
+func NewArgvInput(argv []string) *ArgvInput {
+	if nil == argv {
+		argv = os.Args
+	}
+
+	var tokens []string
+	if len(argv) > 0 {
+		tokens = append(tokens, argv[1:]...)
+	}
+
+	return &ArgvInput{
+		tokens: tokens,
+		Input:  NewInput(),
+	}
+}
+
+// GetTokens returns the tokens of the input, without the program name
+func (ai *ArgvInput) GetTokens() []string {
+	return ai.tokens
+}
+
+// Bind binds the input to the definition and parses the tokens,
+// the options not given fall back on their environment variable and on the config
+func (ai *ArgvInput) Bind(definition *InputDefinition) error {
+	ai.bind(definition)
+	if err := ai.parse(); nil != err {
+		return err
+	}
+	return ai.resolve()
+}
+
+// parse parses the tokens according to the definition
+func (ai *ArgvInput) parse() error {
+	parseOptions := true
+	ai.parsed = append([]string(nil), ai.tokens...)
+
+	for len(ai.parsed) > 0 {
+		token := ai.shift()
+
+		var err error
+		switch {
+		case parseOptions && "--" == token:
+			parseOptions = false
+		case parseOptions && strings.HasPrefix(token, "--"):
+			err = ai.parseLongOption(token)
+		case parseOptions && strings.HasPrefix(token, "-") && "-" != token:
+			err = ai.parseShortOption(token)
+		default:
+			err = ai.parseArgument(token)
+		}
+		if nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// shift removes and returns the next token to parse
+func (ai *ArgvInput) shift() string {
+	token := ai.parsed[0]
+	ai.parsed = ai.parsed[1:]
+	return token
+}
+
+// parseShortOption parses a short option token like -v, -ovalue or -abc
+func (ai *ArgvInput) parseShortOption(token string) error {
+	name := []rune(token[1:])
+
+	if len(name) > 1 {
+		if option, err := ai.definition.GetOptionForShortcut(string(name[0])); nil == err && option.AcceptValue() {
+			// an option with a value and no space
+			return ai.addLongOption("-"+string(name[0]), option.GetName(), string(name[1:]), true)
+		}
+		return ai.parseShortOptionSet(name)
+	}
+
+	return ai.addShortOption(string(name))
+}
+
+// parseShortOptionSet parses a cluster of short options like -abc,
+// the first option accepting a value takes the rest of the cluster as value
+func (ai *ArgvInput) parseShortOptionSet(name []rune) error {
+	for i, shortcut := range name {
+		option, err := ai.definition.GetOptionForShortcut(string(shortcut))
+		if nil != err {
+			return &OptionNotFoundError{Option: "-" + string(shortcut)}
+		}
+
+		if option.AcceptValue() {
+			if i == len(name)-1 {
+				return ai.addLongOption("-"+string(shortcut), option.GetName(), "", false)
+			}
+			return ai.addLongOption("-"+string(shortcut), option.GetName(), string(name[i+1:]), true)
+		}
+
+		if err = ai.addLongOption("-"+string(shortcut), option.GetName(), "", false); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseLongOption parses a long option token like --name, --name=value or --no-name
+func (ai *ArgvInput) parseLongOption(token string) error {
+	name := token[2:]
+
+	if pos := strings.Index(name, "="); -1 != pos {
+		return ai.addLongOption("--"+name[:pos], name[:pos], name[pos+1:], true)
+	}
+	return ai.addLongOption(token, name, "", false)
+}
+
+// parseArgument parses an argument token
+func (ai *ArgvInput) parseArgument(token string) error {
+	arguments := ai.definition.GetArguments()
+	count := len(ai.arguments)
+
+	if count < len(arguments) {
+		argument := arguments[count]
+		if argument.IsArray() {
+			ai.arguments[argument.GetName()] = []string{token}
+		} else {
+			ai.arguments[argument.GetName()] = token
+		}
+		return nil
+	}
+
+	if count > 0 && count == len(arguments) && arguments[count-1].IsArray() {
+		name := arguments[count-1].GetName()
+		ai.arguments[name] = append(ai.arguments[name].([]string), token)
+		return nil
+	}
+
+	expected := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		expected = append(expected, argument.GetName())
+	}
+	return &TooManyArgumentsError{Argument: token, Expected: expected}
+}
+
+// addShortOption adds the value of the option with given shortcut
+func (ai *ArgvInput) addShortOption(shortcut string) error {
+	name, err := ai.definition.ShortcutToName(shortcut)
+	if nil != err {
+		return &OptionNotFoundError{Option: "-" + shortcut}
+	}
+	return ai.addLongOption("-"+shortcut, name, "", false)
+}
+
+// addLongOption adds the value of the option with given name, typed as token like "--name" or "-n".
+// When the value is not given, an option requiring a value takes the next token whatever it is,
+// like "--output -" or "-e -5", and an option with an optional value takes it unless it is an option itself.
+func (ai *ArgvInput) addLongOption(token string, name string, value string, hasValue bool) error {
+	option, err := ai.definition.GetOption(name)
+	if nil != err {
+		negated, err := ai.definition.NegationToName(name)
+		if nil != err {
+			return &OptionNotFoundError{Option: token}
+		}
+		if hasValue {
+			return &OptionValueNotAcceptedError{Option: token}
+		}
+		ai.options[negated] = false
+		return nil
+	}
+
+	if hasValue && !option.AcceptValue() {
+		return &OptionValueNotAcceptedError{Option: token}
+	}
+
+	if !hasValue && option.AcceptValue() && len(ai.parsed) > 0 {
+		if next := ai.parsed[0]; option.IsValueRequired() || "" == next || !strings.HasPrefix(next, "-") {
+			value, hasValue = ai.shift(), true
+		}
+	}
+
+	var optionValue interface{}
+	switch {
+	case hasValue:
+		optionValue = value
+	case option.IsValueRequired():
+		return &OptionValueRequiredError{Option: token}
+	case !option.AcceptValue():
+		optionValue = true
+	}
+
+	if option.IsArray() {
+		values, ok := ai.options[name].([]string)
+		if !ok {
+			values = []string{}
+		}
+		if s, ok := optionValue.(string); ok {
+			values = append(values, s)
+		}
+		ai.options[name] = values
+	} else {
+		ai.options[name] = optionValue
+	}
+
+	return nil
+}
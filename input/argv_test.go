@@ -0,0 +1,291 @@
+package input
+
+import (
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func createDefinition(t *testing.T) *InputDefinition {
+	t.Helper()
+	d := NewInputDefinition()
+
+	err := d.SetArguments(
+		mustArgument(t, "name", ArgumentRequired, nil),
+		mustArgument(t, "files", ArgumentIsArray, nil),
+	)
+	if nil != err {
+		t.Fatal(err)
+	}
+	err = d.SetOptions(
+		mustOption(t, "verbose", "v", OptionValueNone, nil),
+		mustOption(t, "force", "f", OptionValueNone, nil),
+		mustOption(t, "env", "e", OptionValueRequired, "dev"),
+		mustOption(t, "color", "c", OptionValueOptional, "auto"),
+		mustOption(t, "tag", "t", OptionValueRequired|OptionValueIsArray, nil),
+		mustOption(t, "ansi", "", OptionValueNegatable, nil),
+	)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+func TestNewArgvInput(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(NewArgvInput([]string{"cli", "foo", "--bar"}).GetTokens(), qt.DeepEquals, []string{"foo", "--bar"})
+	c.Assert(NewArgvInput([]string{}).GetTokens(), qt.IsNil)
+	c.Assert(NewArgvInput(nil).GetTokens(), qt.DeepEquals, os.Args[1:])
+}
+
+func TestArgvInput_Parse(t *testing.T) {
+	type cs struct {
+		Name      string
+		Argv      []string
+		Arguments map[string]interface{}
+		Options   map[string]interface{}
+	}
+	cases := []cs{
+		{
+			Name:      "arguments",
+			Argv:      []string{"john", "a.txt", "b.txt"},
+			Arguments: map[string]interface{}{"name": "john", "files": []string{"a.txt", "b.txt"}},
+		},
+		{
+			Name:    "long option without value",
+			Argv:    []string{"john", "--verbose"},
+			Options: map[string]interface{}{"verbose": true},
+		},
+		{
+			Name:    "long option with equal sign",
+			Argv:    []string{"john", "--env=prod"},
+			Options: map[string]interface{}{"env": "prod"},
+		},
+		{
+			Name:    "long option with empty value",
+			Argv:    []string{"john", "--env="},
+			Options: map[string]interface{}{"env": ""},
+		},
+		{
+			Name:    "long option value containing equal sign",
+			Argv:    []string{"john", "--env=a=b"},
+			Options: map[string]interface{}{"env": "a=b"},
+		},
+		{
+			Name:      "long option with separate value",
+			Argv:      []string{"--env", "prod", "john"},
+			Arguments: map[string]interface{}{"name": "john", "files": []string{}},
+			Options:   map[string]interface{}{"env": "prod"},
+		},
+		{
+			Name:    "long option with empty separate value",
+			Argv:    []string{"john", "--env", ""},
+			Options: map[string]interface{}{"env": ""},
+		},
+		{
+			Name:      "optional value without value",
+			Argv:      []string{"--color", "--verbose", "john"},
+			Arguments: map[string]interface{}{"name": "john", "files": []string{}},
+			Options:   map[string]interface{}{"color": nil, "verbose": true},
+		},
+		{
+			Name:      "optional value takes next token",
+			Argv:      []string{"--color", "always", "john"},
+			Arguments: map[string]interface{}{"name": "john", "files": []string{}},
+			Options:   map[string]interface{}{"color": "always"},
+		},
+		{
+			Name:    "optional value at the end",
+			Argv:    []string{"john", "-c"},
+			Options: map[string]interface{}{"color": nil},
+		},
+		{
+			Name:    "short option",
+			Argv:    []string{"john", "-v"},
+			Options: map[string]interface{}{"verbose": true},
+		},
+		{
+			Name:    "short option with attached value",
+			Argv:    []string{"john", "-eprod"},
+			Options: map[string]interface{}{"env": "prod"},
+		},
+		{
+			Name:    "short option with separate value",
+			Argv:    []string{"john", "-e", "prod"},
+			Options: map[string]interface{}{"env": "prod"},
+		},
+		{
+			Name:    "short option cluster",
+			Argv:    []string{"john", "-vf"},
+			Options: map[string]interface{}{"verbose": true, "force": true},
+		},
+		{
+			Name:    "short option cluster ending with value option",
+			Argv:    []string{"john", "-vfe", "prod"},
+			Options: map[string]interface{}{"verbose": true, "force": true, "env": "prod"},
+		},
+		{
+			Name:    "short option cluster with attached value",
+			Argv:    []string{"john", "-vfeprod"},
+			Options: map[string]interface{}{"verbose": true, "force": true, "env": "prod"},
+		},
+		{
+			Name:    "value option takes the rest of the cluster",
+			Argv:    []string{"john", "-evf"},
+			Options: map[string]interface{}{"env": "vf"},
+		},
+		{
+			Name:    "repeated array option",
+			Argv:    []string{"john", "--tag=a", "-t", "b", "-tc", "--tag", "d"},
+			Options: map[string]interface{}{"tag": []string{"a", "b", "c", "d"}},
+		},
+		{
+			Name:    "negatable option",
+			Argv:    []string{"john", "--ansi"},
+			Options: map[string]interface{}{"ansi": true},
+		},
+		{
+			Name:    "negated option",
+			Argv:    []string{"john", "--no-ansi"},
+			Options: map[string]interface{}{"ansi": false},
+		},
+		{
+			Name:      "end of options",
+			Argv:      []string{"--verbose", "--", "-john", "--force", "-"},
+			Arguments: map[string]interface{}{"name": "-john", "files": []string{"--force", "-"}},
+			Options:   map[string]interface{}{"verbose": true, "force": false},
+		},
+		{
+			Name:    "value starting with dash is not taken",
+			Argv:    []string{"john", "--color", "-v"},
+			Options: map[string]interface{}{"color": nil, "verbose": true},
+		},
+		{
+			Name:    "required value starting with dash is taken",
+			Argv:    []string{"john", "-e", "-5"},
+			Options: map[string]interface{}{"env": "-5", "verbose": false},
+		},
+		{
+			Name:    "required value of long option is a single dash",
+			Argv:    []string{"john", "--env", "-"},
+			Options: map[string]interface{}{"env": "-"},
+		},
+		{
+			Name:    "required value looking like an option is taken",
+			Argv:    []string{"john", "--env", "--verbose", "-t", "--force"},
+			Options: map[string]interface{}{"env": "--verbose", "verbose": false, "tag": []string{"--force"}, "force": false},
+		},
+		{
+			Name:      "single dash is an argument",
+			Argv:      []string{"-"},
+			Arguments: map[string]interface{}{"name": "-", "files": []string{}},
+		},
+		{
+			Name:      "empty argument",
+			Argv:      []string{"", ""},
+			Arguments: map[string]interface{}{"name": "", "files": []string{""}},
+		},
+		{
+			Name:    "last option wins",
+			Argv:    []string{"john", "--env=prod", "-e", "test"},
+			Options: map[string]interface{}{"env": "test"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			input := NewArgvInput(append([]string{"cli"}, testCase.Argv...))
+
+			c.Assert(input.Bind(createDefinition(t)), qt.IsNil)
+			c.Assert(input.Validate(), qt.IsNil)
+
+			arguments := input.GetArguments()
+			for name, expected := range testCase.Arguments {
+				c.Assert(arguments[name], qt.DeepEquals, expected, qt.Commentf("argument %s", name))
+			}
+			options := input.GetOptions()
+			for name, expected := range testCase.Options {
+				c.Assert(options[name], qt.DeepEquals, expected, qt.Commentf("option %s", name))
+			}
+		})
+	}
+}
+
+func TestArgvInput_ParseErrors(t *testing.T) {
+	type cs struct {
+		Name   string
+		Argv   []string
+		Target interface{}
+		Error  string
+	}
+	cases := []cs{
+		{Name: "unknown long option", Argv: []string{"--foo"}, Target: &OptionNotFoundError{}, Error: `The "--foo" option does not exist.`},
+		{Name: "unknown long option with value", Argv: []string{"--foo=bar"}, Target: &OptionNotFoundError{}, Error: `The "--foo" option does not exist.`},
+		{Name: "unknown short option", Argv: []string{"-x"}, Target: &OptionNotFoundError{}, Error: `The "-x" option does not exist.`},
+		{Name: "unknown short option in cluster", Argv: []string{"-vxf"}, Target: &OptionNotFoundError{}, Error: `The "-x" option does not exist.`},
+		{Name: "unknown negation", Argv: []string{"--no-verbose"}, Target: &OptionNotFoundError{}, Error: `The "--no-verbose" option does not exist.`},
+		{Name: "missing value", Argv: []string{"john", "--env"}, Target: &OptionValueRequiredError{}, Error: `The "--env" option requires a value.`},
+		{Name: "missing value at the end of a cluster", Argv: []string{"john", "-ve"}, Target: &OptionValueRequiredError{}, Error: `The "-e" option requires a value.`},
+		{Name: "value of option without value", Argv: []string{"--verbose=yes"}, Target: &OptionValueNotAcceptedError{}, Error: `The "--verbose" option does not accept a value.`},
+		{Name: "value of negated option", Argv: []string{"--no-ansi=yes"}, Target: &OptionValueNotAcceptedError{}, Error: `The "--no-ansi" option does not accept a value.`},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			err := NewArgvInput(append([]string{"cli"}, testCase.Argv...)).Bind(createDefinition(t))
+
+			c.Assert(err, qt.ErrorMatches, testCase.Error)
+			c.Assert(reflect.TypeOf(err), qt.Equals, reflect.TypeOf(testCase.Target))
+		})
+	}
+}
+
+func TestArgvInput_TooManyArguments(t *testing.T) {
+	c := qt.New(t)
+	d := NewInputDefinition()
+
+	err := NewArgvInput([]string{"cli", "foo"}).Bind(d)
+	c.Assert(err, qt.ErrorMatches, `No arguments expected, got "foo".`)
+
+	c.Assert(d.AddArguments(mustArgument(t, "name", 0, nil), mustArgument(t, "greeting", 0, nil)), qt.IsNil)
+	err = NewArgvInput([]string{"cli", "john", "hello", "extra"}).Bind(d)
+	c.Assert(err, qt.ErrorMatches, `Too many arguments, expected arguments "name" "greeting".`)
+
+	var tooMany *TooManyArgumentsError
+	c.Assert(errors.As(err, &tooMany), qt.IsTrue)
+	c.Assert(tooMany.Argument, qt.Equals, "extra")
+}
+
+func TestArgvInput_NotEnoughArguments(t *testing.T) {
+	c := qt.New(t)
+	d := NewInputDefinition()
+	c.Assert(d.AddArguments(mustArgument(t, "source", ArgumentRequired, nil), mustArgument(t, "target", ArgumentRequired, nil)), qt.IsNil)
+	input := NewArgvInput([]string{"cli"})
+
+	c.Assert(input.Bind(d), qt.IsNil)
+	err := input.Validate()
+	c.Assert(err, qt.ErrorMatches, `Not enough arguments \(missing: "source", "target"\).`)
+
+	var notEnough *NotEnoughArgumentsError
+	c.Assert(errors.As(err, &notEnough), qt.IsTrue)
+	c.Assert(notEnough.Missing, qt.DeepEquals, []string{"source", "target"})
+}
+
+func TestArgvInput_Rebind(t *testing.T) {
+	c := qt.New(t)
+	input := NewArgvInput([]string{"cli", "john", "--verbose"})
+
+	c.Assert(input.Bind(createDefinition(t)), qt.IsNil)
+	c.Assert(input.GetArguments()["name"], qt.Equals, "john")
+
+	d := createDefinition(t)
+	c.Assert(d.SetArguments(mustArgument(t, "user", 0, nil)), qt.IsNil)
+	c.Assert(input.Bind(d), qt.IsNil)
+	c.Assert(input.GetArguments(), qt.DeepEquals, map[string]interface{}{"user": "john"})
+}
@@ -0,0 +1,188 @@
+package input
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ArrayInput represents an input given as a map of parameters, to call a command from code.
+// Keys starting with "--" are long options, keys starting with "-" are shortcuts
+// and other keys are argument names, like {"name": "John", "--force": true, "-e": "prod"}.
+// Values are strings, numbers of any integer or float type are accepted and formatted. Values of array arguments and options
+// are []string or []interface{} of such values, a single value is accepted as a list of one value.
+// Options without value accept a bool, or nil meaning the option is given.
+type ArrayInput struct {
+	parameters map[string]interface{}
+	*Input
+}
+
+// NewArrayInput creates new ArrayInput object from parameters
+func NewArrayInput(parameters map[string]interface{}) *ArrayInput {
+	return &ArrayInput{
+		parameters: parameters,
+		Input:      NewInput(),
+	}
+}
+
+// GetParameters returns the parameters of the input
+func (ai *ArrayInput) GetParameters() map[string]interface{} {
+	return ai.parameters
+}
+
+// Bind binds the input to the definition and parses the parameters,
+// the options not given fall back on their environment variable and on the config
+func (ai *ArrayInput) Bind(definition *InputDefinition) error {
+	ai.bind(definition)
+	if err := ai.parse(); nil != err {
+		return err
+	}
+	return ai.resolve()
+}
+
+// parse parses the parameters according to the definition, in key order
+func (ai *ArrayInput) parse() error {
+	keys := make([]string, 0, len(ai.parameters))
+	for key := range ai.parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var err error
+		value := ai.parameters[key]
+
+		switch {
+		case strings.HasPrefix(key, "--"):
+			err = ai.addLongOption(key, key[2:], value)
+		case strings.HasPrefix(key, "-"):
+			err = ai.addShortOption(key[1:], value)
+		default:
+			err = ai.addArgument(key, value)
+		}
+		if nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addShortOption adds the value of the option with given shortcut
+func (ai *ArrayInput) addShortOption(shortcut string, value interface{}) error {
+	name, err := ai.definition.ShortcutToName(shortcut)
+	if nil != err {
+		return &OptionNotFoundError{Option: "-" + shortcut}
+	}
+	return ai.addLongOption("-"+shortcut, name, value)
+}
+
+// addLongOption adds the value of the option with given name, given as token like "--name" or "-n".
+// A nil value means the option is given without value.
+func (ai *ArrayInput) addLongOption(token string, name string, value interface{}) error {
+	option, err := ai.definition.GetOption(name)
+	if nil != err {
+		negated, err := ai.definition.NegationToName(name)
+		if nil != err {
+			return &OptionNotFoundError{Option: token}
+		}
+		if b, ok := value.(bool); ok {
+			ai.options[negated] = !b
+			return nil
+		}
+		if nil != value {
+			return &OptionValueNotAcceptedError{Option: token}
+		}
+		ai.options[negated] = false
+		return nil
+	}
+
+	switch {
+	case !option.AcceptValue():
+		if nil == value {
+			value = true
+		} else if _, ok := value.(bool); !ok {
+			return &OptionValueNotAcceptedError{Option: token}
+		}
+	case nil == value && option.IsValueRequired():
+		return &OptionValueRequiredError{Option: token}
+	case option.IsArray():
+		values, err := arrayValue(token, value)
+		if nil != err {
+			return err
+		}
+		value = values
+	case nil != value:
+		s, err := stringValue(token, value)
+		if nil != err {
+			return err
+		}
+		value = s
+	}
+	ai.options[name] = value
+
+	return nil
+}
+
+// addArgument adds the value of the argument with given name,
+// a nil value means the argument is not given
+func (ai *ArrayInput) addArgument(name string, value interface{}) error {
+	argument, err := ai.definition.GetArgument(name)
+	if nil != err {
+		return &ArgumentNotFoundError{Argument: name}
+	}
+	if nil == value {
+		return nil
+	}
+
+	if argument.IsArray() {
+		value, err = arrayValue(name, value)
+	} else {
+		value, err = stringValue(name, value)
+	}
+	if nil != err {
+		return err
+	}
+	ai.arguments[name] = value
+
+	return nil
+}
+
+// arrayValue returns the value of an array argument or option as a []string,
+// a single value is a list of one value
+func arrayValue(name string, value interface{}) ([]string, error) {
+	invalid := &InvalidValueError{Name: name, Value: value, Expected: "a list of strings"}
+
+	switch typed := value.(type) {
+	case nil:
+		return []string{}, nil
+	case []string:
+		return typed, nil
+	case []interface{}:
+		values := make([]string, 0, len(typed))
+		for _, v := range typed {
+			s, err := stringValue(name, v)
+			if nil != err {
+				return nil, invalid
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	}
+
+	s, err := stringValue(name, value)
+	if nil != err {
+		return nil, invalid
+	}
+	return []string{s}, nil
+}
+
+// stringValue returns the value of an argument or option as a string, numbers are formatted
+func stringValue(name string, value interface{}) (string, error) {
+	if reflect.Bool != reflect.ValueOf(value).Kind() {
+		if s, ok := scalarString(value); ok {
+			return s, nil
+		}
+	}
+	return "", &InvalidValueError{Name: name, Value: value, Expected: "a string"}
+}
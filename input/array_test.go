@@ -0,0 +1,175 @@
+package input
+
+import (
+	qt "github.com/frankban/quicktest"
+	"reflect"
+	"testing"
+)
+
+func TestArrayInput_Parse(t *testing.T) {
+	type cs struct {
+		Name       string
+		Parameters map[string]interface{}
+		Arguments  map[string]interface{}
+		Options    map[string]interface{}
+	}
+	cases := []cs{
+		{
+			Name:       "arguments",
+			Parameters: map[string]interface{}{"name": "john", "files": []string{"a.txt", "b.txt"}},
+			Arguments:  map[string]interface{}{"name": "john", "files": []string{"a.txt", "b.txt"}},
+		},
+		{
+			Name:       "single value of array argument",
+			Parameters: map[string]interface{}{"name": "john", "files": "a.txt"},
+			Arguments:  map[string]interface{}{"files": []string{"a.txt"}},
+		},
+		{
+			Name:       "missing array argument",
+			Parameters: map[string]interface{}{"name": "john"},
+			Arguments:  map[string]interface{}{"files": []string{}},
+		},
+		{
+			Name:       "long options",
+			Parameters: map[string]interface{}{"name": "john", "--verbose": true, "--env": "prod"},
+			Options:    map[string]interface{}{"verbose": true, "force": false, "env": "prod"},
+		},
+		{
+			Name:       "option without value given nil",
+			Parameters: map[string]interface{}{"name": "john", "--force": nil},
+			Options:    map[string]interface{}{"force": true},
+		},
+		{
+			Name:       "option without value given false",
+			Parameters: map[string]interface{}{"name": "john", "--force": false},
+			Options:    map[string]interface{}{"force": false},
+		},
+		{
+			Name:       "shortcuts",
+			Parameters: map[string]interface{}{"name": "john", "-v": true, "-e": "test"},
+			Options:    map[string]interface{}{"verbose": true, "env": "test"},
+		},
+		{
+			Name:       "optional value given nil",
+			Parameters: map[string]interface{}{"name": "john", "--color": nil},
+			Options:    map[string]interface{}{"color": nil},
+		},
+		{
+			Name:       "array option",
+			Parameters: map[string]interface{}{"name": "john", "--tag": []string{"a", "b"}},
+			Options:    map[string]interface{}{"tag": []string{"a", "b"}},
+		},
+		{
+			Name:       "single value of array option",
+			Parameters: map[string]interface{}{"name": "john", "-t": "a"},
+			Options:    map[string]interface{}{"tag": []string{"a"}},
+		},
+		{
+			Name:       "negatable option",
+			Parameters: map[string]interface{}{"name": "john", "--ansi": true},
+			Options:    map[string]interface{}{"ansi": true},
+		},
+		{
+			Name:       "negated option",
+			Parameters: map[string]interface{}{"name": "john", "--no-ansi": true},
+			Options:    map[string]interface{}{"ansi": false},
+		},
+		{
+			Name:       "negated option given false",
+			Parameters: map[string]interface{}{"name": "john", "--no-ansi": false},
+			Options:    map[string]interface{}{"ansi": true},
+		},
+		{
+			Name:       "numbers are formatted",
+			Parameters: map[string]interface{}{"name": 42, "files": []interface{}{"a", 1, 2.5}, "--tag": 42, "--env": int64(8080)},
+			Arguments:  map[string]interface{}{"name": "42", "files": []string{"a", "1", "2.5"}},
+			Options:    map[string]interface{}{"tag": []string{"42"}, "env": "8080"},
+		},
+		{
+			Name:       "numbers of any size are formatted",
+			Parameters: map[string]interface{}{"name": uint16(80), "files": []interface{}{int8(-1), uint64(18446744073709551615), float32(0.1)}, "--env": int32(443)},
+			Arguments:  map[string]interface{}{"name": "80", "files": []string{"-1", "18446744073709551615", "0.1"}},
+			Options:    map[string]interface{}{"env": "443"},
+		},
+		{
+			Name:       "nil argument is not given",
+			Parameters: map[string]interface{}{"name": "john", "files": nil},
+			Arguments:  map[string]interface{}{"files": []string{}},
+		},
+		{
+			Name:       "defaults",
+			Parameters: map[string]interface{}{"name": "john"},
+			Options:    map[string]interface{}{"env": "dev", "color": "auto", "tag": []string{}, "ansi": nil},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			input := NewArrayInput(testCase.Parameters)
+
+			c.Assert(input.Bind(createDefinition(t)), qt.IsNil)
+			c.Assert(input.Validate(), qt.IsNil)
+
+			arguments := input.GetArguments()
+			for name, expected := range testCase.Arguments {
+				c.Assert(arguments[name], qt.DeepEquals, expected, qt.Commentf("argument %s", name))
+			}
+			options := input.GetOptions()
+			for name, expected := range testCase.Options {
+				c.Assert(options[name], qt.DeepEquals, expected, qt.Commentf("option %s", name))
+			}
+		})
+	}
+}
+
+func TestArrayInput_ParseErrors(t *testing.T) {
+	type cs struct {
+		Name       string
+		Parameters map[string]interface{}
+		Target     interface{}
+		Error      string
+	}
+	cases := []cs{
+		{Name: "unknown argument", Parameters: map[string]interface{}{"foo": "bar"}, Target: &ArgumentNotFoundError{}, Error: `The "foo" argument does not exist.`},
+		{Name: "unknown long option", Parameters: map[string]interface{}{"--foo": true}, Target: &OptionNotFoundError{}, Error: `The "--foo" option does not exist.`},
+		{Name: "unknown short option", Parameters: map[string]interface{}{"-x": true}, Target: &OptionNotFoundError{}, Error: `The "-x" option does not exist.`},
+		{Name: "unknown negation", Parameters: map[string]interface{}{"--no-verbose": true}, Target: &OptionNotFoundError{}, Error: `The "--no-verbose" option does not exist.`},
+		{Name: "missing value", Parameters: map[string]interface{}{"--env": nil}, Target: &OptionValueRequiredError{}, Error: `The "--env" option requires a value.`},
+		{Name: "missing value of shortcut", Parameters: map[string]interface{}{"-e": nil}, Target: &OptionValueRequiredError{}, Error: `The "-e" option requires a value.`},
+		{Name: "value of shortcut without value", Parameters: map[string]interface{}{"-v": "yes"}, Target: &OptionValueNotAcceptedError{}, Error: `The "-v" option does not accept a value.`},
+		{Name: "list with a number", Parameters: map[string]interface{}{"files": []interface{}{"a", true}}, Target: &InvalidValueError{}, Error: `The value of "files" must be a list of strings, \[\]interface {} given.`},
+		{Name: "argument not a string", Parameters: map[string]interface{}{"name": []string{"john"}}, Target: &InvalidValueError{}, Error: `The value of "name" must be a string, \[\]string given.`},
+		{Name: "array option not a list", Parameters: map[string]interface{}{"--tag": map[string]string{}}, Target: &InvalidValueError{}, Error: `The value of "--tag" must be a list of strings, map\[string\]string given.`},
+		{Name: "array option shortcut not a list", Parameters: map[string]interface{}{"-t": false}, Target: &InvalidValueError{}, Error: `The value of "-t" must be a list of strings, bool given.`},
+		{Name: "bool value of option requiring a value", Parameters: map[string]interface{}{"--env": true}, Target: &InvalidValueError{}, Error: `The value of "--env" must be a string, bool given.`},
+		{
+			Name:       "mixed invalid values",
+			Parameters: map[string]interface{}{"files": []interface{}{"a", 1}, "--tag": 42, "--env": true},
+			Target:     &InvalidValueError{},
+			Error:      `The value of "--env" must be a string, bool given.`,
+		},
+		{Name: "bool value of option with optional value", Parameters: map[string]interface{}{"--color": false}, Target: &InvalidValueError{}, Error: `The value of "--color" must be a string, bool given.`},
+		{Name: "value of option without value", Parameters: map[string]interface{}{"--verbose": "yes"}, Target: &OptionValueNotAcceptedError{}, Error: `The "--verbose" option does not accept a value.`},
+		{Name: "value of negated option", Parameters: map[string]interface{}{"--no-ansi": "yes"}, Target: &OptionValueNotAcceptedError{}, Error: `The "--no-ansi" option does not accept a value.`},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			err := NewArrayInput(testCase.Parameters).Bind(createDefinition(t))
+
+			c.Assert(err, qt.ErrorMatches, testCase.Error)
+			c.Assert(reflect.TypeOf(err), qt.Equals, reflect.TypeOf(testCase.Target))
+		})
+	}
+}
+
+func TestArrayInput_Validate(t *testing.T) {
+	c := qt.New(t)
+	input := NewArrayInput(map[string]interface{}{"--verbose": true})
+
+	c.Assert(input.Bind(createDefinition(t)), qt.IsNil)
+	c.Assert(input.Validate(), qt.ErrorMatches, `Not enough arguments \(missing: "name"\).`)
+	c.Assert(input.GetParameters(), qt.DeepEquals, map[string]interface{}{"--verbose": true})
+}
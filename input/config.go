@@ -0,0 +1,81 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadConfig reads option values keyed by option name from a JSON or INI file, chosen by the file extension.
+// A JSON file holds an object like {"env": "prod", "tag": ["a", "b"], "verbose": true}.
+// An INI file holds "name = value" lines, lines starting with ";" or "#" are comments
+// and quoted values are unquoted. Sections are rejected, as option names are not namespaced.
+func LoadConfig(path string) (map[string]interface{}, error) {
+	contents, err := os.ReadFile(path)
+	if nil != err {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		config, err = parseJSONConfig(contents)
+	case ".ini":
+		config, err = parseINIConfig(contents)
+	default:
+		return nil, fmt.Errorf(`The config file "%s" is not supported, use a .json or .ini file.`, path)
+	}
+	if nil != err {
+		return nil, fmt.Errorf(`The config file "%s" is invalid: %s`, path, err)
+	}
+
+	return config, nil
+}
+
+// parseJSONConfig parses the object of a JSON config file
+func parseJSONConfig(contents []byte) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	if err := json.Unmarshal(contents, &config); nil != err {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parseINIConfig parses the lines of an INI config file
+func parseINIConfig(contents []byte) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if "" == line || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			return nil, fmt.Errorf(`line %d is a section, sections are not supported`, number)
+		}
+
+		index := strings.Index(line, "=")
+		if index < 1 {
+			return nil, fmt.Errorf(`line %d is not a "name = value" pair`, number)
+		}
+
+		name := strings.TrimSpace(line[:index])
+		config[name] = unquote(strings.TrimSpace(line[index+1:]))
+	}
+
+	return config, scanner.Err()
+}
+
+// unquote removes the single or double quotes around a value
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package input
+
+import (
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name string, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); nil != err {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	type cs struct {
+		Name     string
+		File     string
+		Contents string
+		Expected map[string]interface{}
+	}
+	cases := []cs{
+		{
+			Name:     "json",
+			File:     "app.json",
+			Contents: `{"env": "prod", "port": 8080, "verbose": true, "tag": ["a", "b"]}`,
+			Expected: map[string]interface{}{"env": "prod", "port": float64(8080), "verbose": true, "tag": []interface{}{"a", "b"}},
+		},
+		{
+			Name:     "json with upper case extension",
+			File:     "app.JSON",
+			Contents: `{"env": "prod"}`,
+			Expected: map[string]interface{}{"env": "prod"},
+		},
+		{
+			Name:     "ini",
+			File:     "app.ini",
+			Contents: "; comment\n# comment\n\nenv = prod\ntag=a,b\nname = \"John Doe\"\nquote = 'it''s'\nurl = http://x?a=b\nempty =\n",
+			Expected: map[string]interface{}{
+				"env": "prod", "tag": "a,b", "name": "John Doe", "quote": "it''s", "url": "http://x?a=b", "empty": "",
+			},
+		},
+		{
+			Name:     "empty ini",
+			File:     "app.ini",
+			Contents: "",
+			Expected: map[string]interface{}{},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			config, err := LoadConfig(writeConfig(t, testCase.File, testCase.Contents))
+
+			c.Assert(err, qt.IsNil)
+			c.Assert(config, qt.DeepEquals, testCase.Expected)
+		})
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	type cs struct {
+		Name     string
+		File     string
+		Contents string
+		Error    string
+	}
+	cases := []cs{
+		{Name: "unsupported format", File: "app.yaml", Contents: "env: prod", Error: `The config file ".*app.yaml" is not supported, use a .json or .ini file.`},
+		{Name: "invalid json", File: "app.json", Contents: `{"env": `, Error: `The config file ".*app.json" is invalid: unexpected end of JSON input`},
+		{Name: "json array", File: "app.json", Contents: `["env"]`, Error: `The config file ".*app.json" is invalid: .*`},
+		{Name: "ini line without equal sign", File: "app.ini", Contents: "env = prod\nverbose\n", Error: `The config file ".*app.ini" is invalid: line 2 is not a "name = value" pair`},
+		{Name: "ini section", File: "app.ini", Contents: "env = prod\n[database]\nhost = localhost\n", Error: `The config file ".*app.ini" is invalid: line 2 is a section, sections are not supported`},
+		{Name: "ini line without name", File: "app.ini", Contents: "= prod\n", Error: `The config file ".*app.ini" is invalid: line 1 is not a "name = value" pair`},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := LoadConfig(writeConfig(t, testCase.File, testCase.Contents))
+			c.Assert(err, qt.ErrorMatches, testCase.Error)
+		})
+	}
+}
+
+func TestLoadConfig_NotFound(t *testing.T) {
+	c := qt.New(t)
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "app.json"))
+
+	c.Assert(errors.Is(err, os.ErrNotExist), qt.IsTrue)
+}
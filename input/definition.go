@@ -0,0 +1,286 @@
+package input
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// InputDefinition holds the arguments and options accepted by a command
+type InputDefinition struct {
+	arguments       []*InputArgument
+	options         []*InputOption
+	shortcuts       map[string]string
+	negations       map[string]string
+	requiredCount   int
+	lastArrayArg    *InputArgument
+	lastOptionalArg *InputArgument
+}
+
+// NewInputDefinition creates new empty InputDefinition object
+func NewInputDefinition() *InputDefinition {
+	return &InputDefinition{
+		shortcuts: make(map[string]string),
+		negations: make(map[string]string),
+	}
+}
+
+// SetArguments replaces the arguments of the definition
+func (d *InputDefinition) SetArguments(arguments ...*InputArgument) error {
+	d.arguments = nil
+	d.requiredCount = 0
+	d.lastArrayArg = nil
+	d.lastOptionalArg = nil
+
+	return d.AddArguments(arguments...)
+}
+
+// AddArguments adds arguments to the definition
+func (d *InputDefinition) AddArguments(arguments ...*InputArgument) error {
+	for _, argument := range arguments {
+		if err := d.AddArgument(argument); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddArgument adds an argument to the definition.
+// Required arguments must come before optional ones and an array argument must be the last one.
+func (d *InputDefinition) AddArgument(argument *InputArgument) error {
+	if d.HasArgument(argument.GetName()) {
+		return fmt.Errorf(`An argument with name "%s" already exists.`, argument.GetName())
+	}
+	if nil != d.lastArrayArg {
+		return fmt.Errorf(`Cannot add an argument "%s" after an array argument "%s".`, argument.GetName(), d.lastArrayArg.GetName())
+	}
+	if argument.IsRequired() && nil != d.lastOptionalArg {
+		return fmt.Errorf(`Cannot add a required argument "%s" after an optional one "%s".`, argument.GetName(), d.lastOptionalArg.GetName())
+	}
+
+	if argument.IsArray() {
+		d.lastArrayArg = argument
+	}
+	if argument.IsRequired() {
+		d.requiredCount++
+	} else {
+		d.lastOptionalArg = argument
+	}
+	d.arguments = append(d.arguments, argument)
+
+	return nil
+}
+
+// GetArgument returns the argument with given name
+func (d *InputDefinition) GetArgument(name string) (*InputArgument, error) {
+	for _, argument := range d.arguments {
+		if argument.GetName() == name {
+			return argument, nil
+		}
+	}
+	return nil, fmt.Errorf(`The "%s" argument does not exist.`, name)
+}
+
+// GetArgumentAt returns the argument at given position
+func (d *InputDefinition) GetArgumentAt(position int) (*InputArgument, error) {
+	if position < 0 || position >= len(d.arguments) {
+		return nil, fmt.Errorf(`The "%d" argument does not exist.`, position)
+	}
+	return d.arguments[position], nil
+}
+
+// HasArgument returns whether the definition has an argument with given name
+func (d *InputDefinition) HasArgument(name string) bool {
+	_, err := d.GetArgument(name)
+	return nil == err
+}
+
+// GetArguments returns the arguments in order
+func (d *InputDefinition) GetArguments() []*InputArgument {
+	return d.arguments
+}
+
+// GetArgumentCount returns the maximum number of argument values, math.MaxInt32 with an array argument
+func (d *InputDefinition) GetArgumentCount() int {
+	if nil != d.lastArrayArg {
+		return math.MaxInt32
+	}
+	return len(d.arguments)
+}
+
+// GetArgumentRequiredCount returns the number of required arguments
+func (d *InputDefinition) GetArgumentRequiredCount() int {
+	return d.requiredCount
+}
+
+// GetArgumentDefaults returns the default values of the arguments keyed by name
+func (d *InputDefinition) GetArgumentDefaults() map[string]interface{} {
+	defaults := make(map[string]interface{}, len(d.arguments))
+	for _, argument := range d.arguments {
+		defaults[argument.GetName()] = argument.GetDefault()
+	}
+	return defaults
+}
+
+// SetOptions replaces the options of the definition
+func (d *InputDefinition) SetOptions(options ...*InputOption) error {
+	d.options = nil
+	d.shortcuts = make(map[string]string)
+	d.negations = make(map[string]string)
+
+	return d.AddOptions(options...)
+}
+
+// AddOptions adds options to the definition
+func (d *InputDefinition) AddOptions(options ...*InputOption) error {
+	for _, option := range options {
+		if err := d.AddOption(option); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddOption adds an option to the definition
+func (d *InputDefinition) AddOption(option *InputOption) error {
+	name := option.GetName()
+	if d.HasOption(name) || d.HasNegation(name) {
+		return fmt.Errorf(`An option named "%s" already exists.`, name)
+	}
+	if option.IsNegatable() && (d.HasOption(option.GetNegation()) || d.HasNegation(option.GetNegation())) {
+		return fmt.Errorf(`An option named "%s" already exists.`, option.GetNegation())
+	}
+	for _, shortcut := range option.GetShortcuts() {
+		if d.HasShortcut(shortcut) {
+			return fmt.Errorf(`An option with shortcut "%s" already exists.`, shortcut)
+		}
+	}
+
+	d.options = append(d.options, option)
+	for _, shortcut := range option.GetShortcuts() {
+		d.shortcuts[shortcut] = name
+	}
+	if option.IsNegatable() {
+		d.negations[option.GetNegation()] = name
+	}
+
+	return nil
+}
+
+// GetOption returns the option with given name
+func (d *InputDefinition) GetOption(name string) (*InputOption, error) {
+	for _, option := range d.options {
+		if option.GetName() == name {
+			return option, nil
+		}
+	}
+	return nil, fmt.Errorf(`The "--%s" option does not exist.`, name)
+}
+
+// HasOption returns whether the definition has an option with given name
+func (d *InputDefinition) HasOption(name string) bool {
+	_, err := d.GetOption(name)
+	return nil == err
+}
+
+// GetOptions returns the options in order
+func (d *InputDefinition) GetOptions() []*InputOption {
+	return d.options
+}
+
+// HasShortcut returns whether the definition has an option with given shortcut
+func (d *InputDefinition) HasShortcut(shortcut string) bool {
+	_, ok := d.shortcuts[shortcut]
+	return ok
+}
+
+// HasNegation returns whether the definition has a negatable option with given negation, like "no-ansi"
+func (d *InputDefinition) HasNegation(negation string) bool {
+	_, ok := d.negations[negation]
+	return ok
+}
+
+// GetOptionForShortcut returns the option with given shortcut
+func (d *InputDefinition) GetOptionForShortcut(shortcut string) (*InputOption, error) {
+	name, err := d.ShortcutToName(shortcut)
+	if nil != err {
+		return nil, err
+	}
+	return d.GetOption(name)
+}
+
+// ShortcutToName returns the name of the option with given shortcut
+func (d *InputDefinition) ShortcutToName(shortcut string) (string, error) {
+	name, ok := d.shortcuts[shortcut]
+	if !ok {
+		return "", fmt.Errorf(`The "-%s" option does not exist.`, shortcut)
+	}
+	return name, nil
+}
+
+// NegationToName returns the name of the option negated by given negation
+func (d *InputDefinition) NegationToName(negation string) (string, error) {
+	name, ok := d.negations[negation]
+	if !ok {
+		return "", fmt.Errorf(`The "--%s" option does not exist.`, negation)
+	}
+	return name, nil
+}
+
+// GetOptionDefaults returns the default values of the options keyed by name
+func (d *InputDefinition) GetOptionDefaults() map[string]interface{} {
+	defaults := make(map[string]interface{}, len(d.options))
+	for _, option := range d.options {
+		defaults[option.GetName()] = option.GetDefault()
+	}
+	return defaults
+}
+
+// GetSynopsis returns the synopsis of the definition, like "[-v|--verbose] [--] <name> [<files>...]".
+// The short synopsis replaces the options with "[options]".
+func (d *InputDefinition) GetSynopsis(short bool) string {
+	var elements []string
+
+	if short && len(d.options) > 0 {
+		elements = append(elements, "[options]")
+	} else if !short {
+		for _, option := range d.options {
+			value := ""
+			if option.AcceptValue() {
+				if option.IsValueOptional() {
+					value = fmt.Sprintf(" [%s]", strings.ToUpper(option.GetName()))
+				} else {
+					value = fmt.Sprintf(" %s", strings.ToUpper(option.GetName()))
+				}
+			}
+			shortcut := ""
+			if "" != option.GetShortcut() {
+				shortcut = fmt.Sprintf("-%s|", option.GetShortcut())
+			}
+			negation := ""
+			if option.IsNegatable() {
+				negation = fmt.Sprintf("|--%s", option.GetNegation())
+			}
+			elements = append(elements, fmt.Sprintf("[%s--%s%s%s]", shortcut, option.GetName(), value, negation))
+		}
+	}
+
+	if len(elements) > 0 && len(d.arguments) > 0 {
+		elements = append(elements, "[--]")
+	}
+
+	tail := ""
+	for _, argument := range d.arguments {
+		element := fmt.Sprintf("<%s>", argument.GetName())
+		if argument.IsArray() {
+			element += "..."
+		}
+		if !argument.IsRequired() {
+			element = "[" + element
+			tail += "]"
+		}
+		elements = append(elements, element)
+	}
+
+	return strings.Join(elements, " ") + tail
+}
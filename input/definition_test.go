@@ -0,0 +1,230 @@
+package input
+
+import (
+	qt "github.com/frankban/quicktest"
+	"math"
+	"testing"
+)
+
+func mustArgument(t *testing.T, name string, mode int, defaultValue interface{}) *InputArgument {
+	t.Helper()
+	argument, err := NewInputArgument(name, mode, "", defaultValue)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return argument
+}
+
+func mustOption(t *testing.T, name string, shortcut string, mode int, defaultValue interface{}) *InputOption {
+	t.Helper()
+	option, err := NewInputOption(name, shortcut, mode, "", defaultValue)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return option
+}
+
+func argumentNames(arguments []*InputArgument) []string {
+	var names []string
+	for _, argument := range arguments {
+		names = append(names, argument.GetName())
+	}
+	return names
+}
+
+func optionNames(options []*InputOption) []string {
+	var names []string
+	for _, option := range options {
+		names = append(names, option.GetName())
+	}
+	return names
+}
+
+func TestInputDefinition_Arguments(t *testing.T) {
+	c := qt.New(t)
+	d := NewInputDefinition()
+	name := mustArgument(t, "name", ArgumentRequired, nil)
+	greeting := mustArgument(t, "greeting", ArgumentOptional, "Hello")
+
+	c.Assert(d.AddArguments(name, greeting), qt.IsNil)
+	c.Assert(argumentNames(d.GetArguments()), qt.DeepEquals, []string{"name", "greeting"})
+	c.Assert(d.GetArgumentCount(), qt.Equals, 2)
+	c.Assert(d.GetArgumentRequiredCount(), qt.Equals, 1)
+	c.Assert(d.HasArgument("name"), qt.IsTrue)
+	c.Assert(d.HasArgument("foo"), qt.IsFalse)
+	c.Assert(d.GetArgumentDefaults(), qt.DeepEquals, map[string]interface{}{"name": nil, "greeting": "Hello"})
+
+	argument, err := d.GetArgument("greeting")
+	c.Assert(err, qt.IsNil)
+	c.Assert(argument, qt.Equals, greeting)
+	_, err = d.GetArgument("foo")
+	c.Assert(err, qt.ErrorMatches, `The "foo" argument does not exist.`)
+
+	argument, err = d.GetArgumentAt(0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(argument, qt.Equals, name)
+	_, err = d.GetArgumentAt(2)
+	c.Assert(err, qt.ErrorMatches, `The "2" argument does not exist.`)
+
+	files := mustArgument(t, "files", ArgumentIsArray, nil)
+	c.Assert(d.AddArgument(files), qt.IsNil)
+	c.Assert(d.GetArgumentCount(), qt.Equals, math.MaxInt32)
+
+	c.Assert(d.SetArguments(greeting), qt.IsNil)
+	c.Assert(argumentNames(d.GetArguments()), qt.DeepEquals, []string{"greeting"})
+	c.Assert(d.GetArgumentRequiredCount(), qt.Equals, 0)
+	c.Assert(d.AddArgument(files), qt.IsNil)
+}
+
+func TestInputDefinition_AddArgumentErrors(t *testing.T) {
+	type cs struct {
+		Name      string
+		Arguments []*InputArgument
+		Error     string
+	}
+	cases := []cs{
+		{
+			Name:      "same name",
+			Arguments: []*InputArgument{mustArgument(t, "name", 0, nil), mustArgument(t, "name", 0, nil)},
+			Error:     `An argument with name "name" already exists.`,
+		},
+		{
+			Name:      "after array argument",
+			Arguments: []*InputArgument{mustArgument(t, "files", ArgumentIsArray, nil), mustArgument(t, "name", 0, nil)},
+			Error:     `Cannot add an argument "name" after an array argument "files".`,
+		},
+		{
+			Name:      "required after optional",
+			Arguments: []*InputArgument{mustArgument(t, "greeting", 0, nil), mustArgument(t, "name", ArgumentRequired, nil)},
+			Error:     `Cannot add a required argument "name" after an optional one "greeting".`,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			c.Assert(NewInputDefinition().AddArguments(testCase.Arguments...), qt.ErrorMatches, testCase.Error)
+		})
+	}
+}
+
+func TestInputDefinition_Options(t *testing.T) {
+	c := qt.New(t)
+	d := NewInputDefinition()
+	verbose := mustOption(t, "verbose", "v|vv", OptionValueNone, nil)
+	env := mustOption(t, "env", "e", OptionValueRequired, "dev")
+	ansi := mustOption(t, "ansi", "", OptionValueNegatable, nil)
+
+	c.Assert(d.AddOptions(verbose, env, ansi), qt.IsNil)
+	c.Assert(optionNames(d.GetOptions()), qt.DeepEquals, []string{"verbose", "env", "ansi"})
+	c.Assert(d.HasOption("env"), qt.IsTrue)
+	c.Assert(d.HasOption("no-ansi"), qt.IsFalse)
+	c.Assert(d.HasShortcut("vv"), qt.IsTrue)
+	c.Assert(d.HasShortcut("x"), qt.IsFalse)
+	c.Assert(d.HasNegation("no-ansi"), qt.IsTrue)
+	c.Assert(d.GetOptionDefaults(), qt.DeepEquals, map[string]interface{}{"verbose": false, "env": "dev", "ansi": nil})
+
+	option, err := d.GetOption("env")
+	c.Assert(err, qt.IsNil)
+	c.Assert(option, qt.Equals, env)
+	_, err = d.GetOption("foo")
+	c.Assert(err, qt.ErrorMatches, `The "--foo" option does not exist.`)
+
+	option, err = d.GetOptionForShortcut("vv")
+	c.Assert(err, qt.IsNil)
+	c.Assert(option, qt.Equals, verbose)
+	_, err = d.ShortcutToName("x")
+	c.Assert(err, qt.ErrorMatches, `The "-x" option does not exist.`)
+
+	name, err := d.NegationToName("no-ansi")
+	c.Assert(err, qt.IsNil)
+	c.Assert(name, qt.Equals, "ansi")
+	_, err = d.NegationToName("no-env")
+	c.Assert(err, qt.ErrorMatches, `The "--no-env" option does not exist.`)
+
+	c.Assert(d.SetOptions(env), qt.IsNil)
+	c.Assert(optionNames(d.GetOptions()), qt.DeepEquals, []string{"env"})
+	c.Assert(d.HasShortcut("v"), qt.IsFalse)
+	c.Assert(d.HasNegation("no-ansi"), qt.IsFalse)
+}
+
+func TestInputDefinition_AddOptionErrors(t *testing.T) {
+	type cs struct {
+		Name    string
+		Options []*InputOption
+		Error   string
+	}
+	cases := []cs{
+		{
+			Name:    "same name",
+			Options: []*InputOption{mustOption(t, "env", "", 0, nil), mustOption(t, "env", "", 0, nil)},
+			Error:   `An option named "env" already exists.`,
+		},
+		{
+			Name:    "name of a negation",
+			Options: []*InputOption{mustOption(t, "ansi", "", OptionValueNegatable, nil), mustOption(t, "no-ansi", "", 0, nil)},
+			Error:   `An option named "no-ansi" already exists.`,
+		},
+		{
+			Name:    "negation of an option",
+			Options: []*InputOption{mustOption(t, "no-ansi", "", 0, nil), mustOption(t, "ansi", "", OptionValueNegatable, nil)},
+			Error:   `An option named "no-ansi" already exists.`,
+		},
+		{
+			Name:    "same shortcut",
+			Options: []*InputOption{mustOption(t, "env", "e", 0, nil), mustOption(t, "exclude", "x|e", 0, nil)},
+			Error:   `An option with shortcut "e" already exists.`,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			c.Assert(NewInputDefinition().AddOptions(testCase.Options...), qt.ErrorMatches, testCase.Error)
+		})
+	}
+}
+
+func TestInputDefinition_GetSynopsis(t *testing.T) {
+	type cs struct {
+		Name      string
+		Arguments []*InputArgument
+		Options   []*InputOption
+		Expected  string
+		Short     string
+	}
+	cases := []cs{
+		{Name: "empty", Expected: "", Short: ""},
+		{
+			Name:     "options",
+			Options:  []*InputOption{mustOption(t, "verbose", "v", 0, nil), mustOption(t, "env", "", OptionValueRequired, nil), mustOption(t, "color", "c", OptionValueOptional, nil), mustOption(t, "ansi", "", OptionValueNegatable, nil)},
+			Expected: "[-v|--verbose] [--env ENV] [-c|--color [COLOR]] [--ansi|--no-ansi]",
+			Short:    "[options]",
+		},
+		{
+			Name:      "arguments",
+			Arguments: []*InputArgument{mustArgument(t, "name", ArgumentRequired, nil), mustArgument(t, "greeting", 0, nil), mustArgument(t, "files", ArgumentIsArray, nil)},
+			Expected:  "<name> [<greeting> [<files>...]]",
+			Short:     "<name> [<greeting> [<files>...]]",
+		},
+		{
+			Name:      "options and arguments",
+			Arguments: []*InputArgument{mustArgument(t, "files", ArgumentRequired|ArgumentIsArray, nil)},
+			Options:   []*InputOption{mustOption(t, "force", "f", 0, nil)},
+			Expected:  "[-f|--force] [--] <files>...",
+			Short:     "[options] [--] <files>...",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			d := NewInputDefinition()
+			c.Assert(d.SetArguments(testCase.Arguments...), qt.IsNil)
+			c.Assert(d.SetOptions(testCase.Options...), qt.IsNil)
+
+			c.Assert(d.GetSynopsis(false), qt.Equals, testCase.Expected)
+			c.Assert(d.GetSynopsis(true), qt.Equals, testCase.Short)
+		})
+	}
+}
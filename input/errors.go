@@ -0,0 +1,98 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OptionNotFoundError is returned when the input holds an option missing from the definition
+type OptionNotFoundError struct {
+	// Option is the offending token, like "--foo" or "-f"
+	Option string
+}
+
+// Error returns the error message
+func (e *OptionNotFoundError) Error() string {
+	return fmt.Sprintf(`The "%s" option does not exist.`, e.Option)
+}
+
+// OptionValueRequiredError is returned when an option requiring a value is given without value
+type OptionValueRequiredError struct {
+	Option string
+}
+
+// Error returns the error message
+func (e *OptionValueRequiredError) Error() string {
+	return fmt.Sprintf(`The "%s" option requires a value.`, e.Option)
+}
+
+// OptionValueNotAcceptedError is returned when an option without value is given a value
+type OptionValueNotAcceptedError struct {
+	Option string
+}
+
+// Error returns the error message
+func (e *OptionValueNotAcceptedError) Error() string {
+	return fmt.Sprintf(`The "%s" option does not accept a value.`, e.Option)
+}
+
+// TooManyArgumentsError is returned when the input holds more arguments than the definition
+type TooManyArgumentsError struct {
+	// Argument is the first argument in excess
+	Argument string
+	// Expected holds the names of the arguments of the definition
+	Expected []string
+}
+
+// Error returns the error message
+func (e *TooManyArgumentsError) Error() string {
+	if 0 == len(e.Expected) {
+		return fmt.Sprintf(`No arguments expected, got "%s".`, e.Argument)
+	}
+	return fmt.Sprintf(`Too many arguments, expected arguments "%s".`, strings.Join(e.Expected, `" "`))
+}
+
+// NotEnoughArgumentsError is returned when required arguments are missing from the input
+type NotEnoughArgumentsError struct {
+	Missing []string
+}
+
+// Error returns the error message
+func (e *NotEnoughArgumentsError) Error() string {
+	return fmt.Sprintf(`Not enough arguments (missing: "%s").`, strings.Join(e.Missing, `", "`))
+}
+
+// TokenizeError is returned when a command line string can not be split into tokens,
+// like when a quote is not closed
+type TokenizeError struct {
+	// Near holds the input from the offending character
+	Near string
+}
+
+// Error returns the error message
+func (e *TokenizeError) Error() string {
+	return fmt.Sprintf(`Unable to parse input near "%s".`, e.Near)
+}
+
+// ArgumentNotFoundError is returned when the input holds an argument missing from the definition
+type ArgumentNotFoundError struct {
+	Argument string
+}
+
+// Error returns the error message
+func (e *ArgumentNotFoundError) Error() string {
+	return fmt.Sprintf(`The "%s" argument does not exist.`, e.Argument)
+}
+
+// InvalidValueError is returned when the value of an argument or option has an unexpected type
+type InvalidValueError struct {
+	// Name is the argument name or the option token, like "--foo" or "-f"
+	Name     string
+	Value    interface{}
+	Expected string
+}
+
+// Error returns the error message
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf(`The value of "%s" must be %s, %T given.`, e.Name, e.Expected, e.Value)
+}
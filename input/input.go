@@ -0,0 +1,177 @@
+package input
+
+import (
+	"fmt"
+)
+
+// IInput is implemented by every input
+type IInput interface {
+	Bind(definition *InputDefinition) error
+	Validate() error
+	GetArguments() map[string]interface{}
+	GetArgument(name string) (interface{}, error)
+	SetArgument(name string, value interface{}) error
+	HasArgument(name string) bool
+	GetOptions() map[string]interface{}
+	GetOption(name string) (interface{}, error)
+	SetOption(name string, value interface{}) error
+	HasOption(name string) bool
+	IsInteractive() bool
+	SetInteractive(interactive bool)
+}
+
+// Input holds the argument and option values of an input bound to a definition,
+// it is embedded by every input parsing its own source.
+type Input struct {
+	definition  *InputDefinition
+	arguments   map[string]interface{}
+	options     map[string]interface{}
+	sources     map[string]ValueSource
+	config      map[string]interface{}
+	interactive bool
+}
+
+// NewInput creates new Input object bound to an empty definition
+func NewInput() *Input {
+	return &Input{
+		definition:  NewInputDefinition(),
+		arguments:   make(map[string]interface{}),
+		options:     make(map[string]interface{}),
+		sources:     make(map[string]ValueSource),
+		interactive: true,
+	}
+}
+
+// bind binds the input to the definition, forgetting the values of a previous binding
+func (i *Input) bind(definition *InputDefinition) {
+	i.definition = definition
+	i.arguments = make(map[string]interface{})
+	i.options = make(map[string]interface{})
+	i.sources = make(map[string]ValueSource)
+}
+
+// GetDefinition returns the definition the input is bound to
+func (i *Input) GetDefinition() *InputDefinition {
+	return i.definition
+}
+
+// Validate checks that every required argument is given
+func (i *Input) Validate() error {
+	var missing []string
+
+	for _, argument := range i.definition.GetArguments() {
+		if _, ok := i.arguments[argument.GetName()]; !ok && argument.IsRequired() {
+			missing = append(missing, argument.GetName())
+		}
+	}
+
+	if len(missing) > 0 {
+		return &NotEnoughArgumentsError{Missing: missing}
+	}
+	return nil
+}
+
+// IsInteractive returns whether the input is interactive
+func (i *Input) IsInteractive() bool {
+	return i.interactive
+}
+
+// SetInteractive sets whether the input is interactive
+func (i *Input) SetInteractive(interactive bool) {
+	i.interactive = interactive
+}
+
+// GetArguments returns the argument values keyed by name, including default values
+func (i *Input) GetArguments() map[string]interface{} {
+	arguments := i.definition.GetArgumentDefaults()
+	for name, value := range i.arguments {
+		arguments[name] = value
+	}
+	return arguments
+}
+
+// GetArgument returns the value of the argument with given name, or its default value
+func (i *Input) GetArgument(name string) (interface{}, error) {
+	argument, err := i.definition.GetArgument(name)
+	if nil != err {
+		return nil, err
+	}
+
+	if value, ok := i.arguments[name]; ok {
+		return value, nil
+	}
+	return argument.GetDefault(), nil
+}
+
+// SetArgument sets the value of the argument with given name
+func (i *Input) SetArgument(name string, value interface{}) error {
+	if !i.definition.HasArgument(name) {
+		return fmt.Errorf(`The "%s" argument does not exist.`, name)
+	}
+
+	i.arguments[name] = value
+	return nil
+}
+
+// HasArgument returns whether the definition has an argument with given name
+func (i *Input) HasArgument(name string) bool {
+	return i.definition.HasArgument(name)
+}
+
+// GetOptions returns the option values keyed by name, including default values
+func (i *Input) GetOptions() map[string]interface{} {
+	options := i.definition.GetOptionDefaults()
+	for name, value := range i.options {
+		options[name] = value
+	}
+	return options
+}
+
+// GetOption returns the value of the option with given name, or its default value.
+// The negation of a negatable option, like "no-ansi", returns the negated value.
+func (i *Input) GetOption(name string) (interface{}, error) {
+	if i.definition.HasNegation(name) {
+		optionName, _ := i.definition.NegationToName(name)
+		value, err := i.GetOption(optionName)
+		if b, ok := value.(bool); ok {
+			return !b, err
+		}
+		return value, err
+	}
+
+	option, err := i.definition.GetOption(name)
+	if nil != err {
+		return nil, fmt.Errorf(`The "%s" option does not exist.`, name)
+	}
+
+	if value, ok := i.options[name]; ok {
+		return value, nil
+	}
+	return option.GetDefault(), nil
+}
+
+// SetOption sets the value of the option with given name
+func (i *Input) SetOption(name string, value interface{}) error {
+	if i.definition.HasNegation(name) {
+		optionName, _ := i.definition.NegationToName(name)
+		if b, ok := value.(bool); ok {
+			value = !b
+		}
+		i.options[optionName] = value
+		i.sources[optionName] = SourceInput
+		return nil
+	}
+
+	if !i.definition.HasOption(name) {
+		return fmt.Errorf(`The "%s" option does not exist.`, name)
+	}
+
+	i.options[name] = value
+	i.sources[name] = SourceInput
+	return nil
+}
+
+// HasOption returns whether the definition has an option with given name
+func (i *Input) HasOption(name string) bool {
+	return i.definition.HasOption(name) || i.definition.HasNegation(name)
+}
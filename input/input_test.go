@@ -0,0 +1,83 @@
+package input
+
+import (
+	qt "github.com/frankban/quicktest"
+	"testing"
+)
+
+func TestInput_Arguments(t *testing.T) {
+	c := qt.New(t)
+	input := NewArgvInput([]string{"cli", "john"})
+	c.Assert(input.Bind(createDefinition(t)), qt.IsNil)
+
+	c.Assert(input.HasArgument("name"), qt.IsTrue)
+	c.Assert(input.HasArgument("foo"), qt.IsFalse)
+
+	value, err := input.GetArgument("name")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "john")
+	value, err = input.GetArgument("files")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.DeepEquals, []string{})
+	_, err = input.GetArgument("foo")
+	c.Assert(err, qt.ErrorMatches, `The "foo" argument does not exist.`)
+
+	c.Assert(input.SetArgument("name", "jane"), qt.IsNil)
+	c.Assert(input.GetArguments(), qt.DeepEquals, map[string]interface{}{"name": "jane", "files": []string{}})
+	c.Assert(input.SetArgument("foo", "bar"), qt.ErrorMatches, `The "foo" argument does not exist.`)
+}
+
+func TestInput_Options(t *testing.T) {
+	c := qt.New(t)
+	input := NewArgvInput([]string{"cli", "john", "--env=prod"})
+	c.Assert(input.Bind(createDefinition(t)), qt.IsNil)
+
+	c.Assert(input.HasOption("env"), qt.IsTrue)
+	c.Assert(input.HasOption("no-ansi"), qt.IsTrue)
+	c.Assert(input.HasOption("foo"), qt.IsFalse)
+
+	value, err := input.GetOption("env")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "prod")
+	value, err = input.GetOption("color")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "auto")
+	_, err = input.GetOption("foo")
+	c.Assert(err, qt.ErrorMatches, `The "foo" option does not exist.`)
+
+	// the negation of an option without value is nil
+	value, err = input.GetOption("no-ansi")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.IsNil)
+
+	c.Assert(input.SetOption("no-ansi", true), qt.IsNil)
+	value, _ = input.GetOption("ansi")
+	c.Assert(value, qt.Equals, false)
+	value, _ = input.GetOption("no-ansi")
+	c.Assert(value, qt.Equals, true)
+
+	c.Assert(input.SetOption("verbose", true), qt.IsNil)
+	c.Assert(input.GetOptions(), qt.DeepEquals, map[string]interface{}{
+		"verbose": true,
+		"force":   false,
+		"env":     "prod",
+		"color":   "auto",
+		"tag":     []string{},
+		"ansi":    false,
+	})
+	c.Assert(input.SetOption("foo", "bar"), qt.ErrorMatches, `The "foo" option does not exist.`)
+}
+
+func TestInput_Interactive(t *testing.T) {
+	c := qt.New(t)
+	input := NewInput()
+
+	c.Assert(input.IsInteractive(), qt.IsTrue)
+	input.SetInteractive(false)
+	c.Assert(input.IsInteractive(), qt.IsFalse)
+	c.Assert(input.GetDefinition().GetArguments(), qt.HasLen, 0)
+}
+
+func TestArgvInput_ImplementsIInput(t *testing.T) {
+	var _ IInput = NewArgvInput(nil)
+}
@@ -0,0 +1,173 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Modes of an InputOption, OptionValueIsArray can be combined with OptionValueRequired or OptionValueOptional
+// and OptionValueNegatable with OptionValueNone
+const (
+	OptionValueNone = 1 << iota
+	OptionValueRequired
+	OptionValueOptional
+	OptionValueIsArray
+	OptionValueNegatable
+)
+
+// InputOption represents a command line option
+type InputOption struct {
+	name         string
+	shortcut     string
+	mode         int
+	description  string
+	defaultValue interface{}
+	envVar       string
+}
+
+// NewInputOption creates new InputOption object.
+// The shortcut can hold several shortcuts separated by "|", like "v|vv".
+// A zero mode means OptionValueNone, the default value of an array option must be a []string.
+func NewInputOption(name string, shortcut string, mode int, description string, defaultValue interface{}) (*InputOption, error) {
+	name = strings.TrimPrefix(name, "--")
+	if "" == name {
+		return nil, errors.New("An option name cannot be empty.")
+	}
+
+	if "" != shortcut {
+		var shortcuts []string
+		for _, s := range strings.Split(shortcut, "|") {
+			s = strings.TrimLeft(s, "-")
+			if "" != s {
+				shortcuts = append(shortcuts, s)
+			}
+		}
+		if 0 == len(shortcuts) {
+			return nil, errors.New("An option shortcut cannot be empty.")
+		}
+		shortcut = strings.Join(shortcuts, "|")
+	}
+
+	if 0 == mode {
+		mode = OptionValueNone
+	}
+	if mode >= OptionValueNegatable<<1 || mode < 1 {
+		return nil, fmt.Errorf(`Option mode "%d" is not valid.`, mode)
+	}
+
+	option := &InputOption{
+		name:        name,
+		shortcut:    shortcut,
+		mode:        mode,
+		description: description,
+	}
+	if option.IsArray() && !option.AcceptValue() {
+		return nil, errors.New("Impossible to have an option mode OptionValueIsArray if the option does not accept a value.")
+	}
+	if option.IsNegatable() && option.AcceptValue() {
+		return nil, errors.New("Impossible to have an option mode OptionValueNegatable if the option also accepts a value.")
+	}
+	if err := option.SetDefault(defaultValue); nil != err {
+		return nil, err
+	}
+
+	return option, nil
+}
+
+// GetName returns the option name
+func (o *InputOption) GetName() string {
+	return o.name
+}
+
+// GetShortcut returns the option shortcuts separated by "|"
+func (o *InputOption) GetShortcut() string {
+	return o.shortcut
+}
+
+// GetShortcuts returns the option shortcuts
+func (o *InputOption) GetShortcuts() []string {
+	if "" == o.shortcut {
+		return nil
+	}
+	return strings.Split(o.shortcut, "|")
+}
+
+// AcceptValue returns whether the option accepts a value
+func (o *InputOption) AcceptValue() bool {
+	return o.IsValueRequired() || o.IsValueOptional()
+}
+
+// IsValueRequired returns whether the option requires a value
+func (o *InputOption) IsValueRequired() bool {
+	return o.mode&OptionValueRequired == OptionValueRequired
+}
+
+// IsValueOptional returns whether the option takes an optional value
+func (o *InputOption) IsValueOptional() bool {
+	return o.mode&OptionValueOptional == OptionValueOptional
+}
+
+// IsArray returns whether the option can be given several times to collect multiple values
+func (o *InputOption) IsArray() bool {
+	return o.mode&OptionValueIsArray == OptionValueIsArray
+}
+
+// IsNegatable returns whether the option can be negated with --no-<name>
+func (o *InputOption) IsNegatable() bool {
+	return o.mode&OptionValueNegatable == OptionValueNegatable
+}
+
+// GetNegation returns the name of the negated option, like "no-ansi"
+func (o *InputOption) GetNegation() string {
+	return "no-" + o.name
+}
+
+// GetDescription returns the option description
+func (o *InputOption) GetDescription() string {
+	return o.description
+}
+
+// GetDefault returns the default value.
+// Options without value default to false, negatable options default to nil when given no default,
+// and array options to an empty []string.
+func (o *InputOption) GetDefault() interface{} {
+	return o.defaultValue
+}
+
+// SetDefault sets the default value of the option
+func (o *InputOption) SetDefault(value interface{}) error {
+	if o.mode&OptionValueNone == OptionValueNone && nil != value && !o.IsNegatable() {
+		return errors.New("Cannot set a default value when using OptionValueNone mode.")
+	}
+
+	switch {
+	case o.IsArray():
+		if nil == value {
+			value = []string{}
+		} else if _, ok := value.([]string); !ok {
+			return errors.New("A default value for an array option must be an array.")
+		}
+	case !o.AcceptValue() && !o.IsNegatable():
+		value = false
+	case o.IsNegatable() && nil != value:
+		if _, ok := value.(bool); !ok {
+			return errors.New("A default value for a negatable option must be a boolean.")
+		}
+	}
+
+	o.defaultValue = value
+	return nil
+}
+
+// SetEnvVar sets the name of the environment variable giving the option value
+// when the option is not given in the input. A variable set to an empty string gives an empty value
+// to an option accepting a value, and is considered unset for an option without value.
+func (o *InputOption) SetEnvVar(name string) {
+	o.envVar = name
+}
+
+// GetEnvVar returns the name of the environment variable giving the option value
+func (o *InputOption) GetEnvVar() string {
+	return o.envVar
+}
@@ -0,0 +1,76 @@
+package input
+
+import (
+	qt "github.com/frankban/quicktest"
+	"testing"
+)
+
+func TestNewInputOption(t *testing.T) {
+	c := qt.New(t)
+
+	option, err := NewInputOption("--verbose", "-v|vv|-vvv", 0, "Verbosity", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(option.GetName(), qt.Equals, "verbose")
+	c.Assert(option.GetShortcut(), qt.Equals, "v|vv|vvv")
+	c.Assert(option.GetShortcuts(), qt.DeepEquals, []string{"v", "vv", "vvv"})
+	c.Assert(option.GetDescription(), qt.Equals, "Verbosity")
+	c.Assert(option.AcceptValue(), qt.IsFalse)
+	c.Assert(option.GetDefault(), qt.Equals, false)
+
+	option, err = NewInputOption("env", "e", OptionValueRequired, "", "dev")
+	c.Assert(err, qt.IsNil)
+	c.Assert(option.AcceptValue(), qt.IsTrue)
+	c.Assert(option.IsValueRequired(), qt.IsTrue)
+	c.Assert(option.IsValueOptional(), qt.IsFalse)
+	c.Assert(option.GetDefault(), qt.Equals, "dev")
+
+	option, err = NewInputOption("color", "", OptionValueOptional, "", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(option.IsValueOptional(), qt.IsTrue)
+	c.Assert(option.GetShortcuts(), qt.IsNil)
+	c.Assert(option.GetDefault(), qt.IsNil)
+
+	option, err = NewInputOption("tag", "t", OptionValueRequired|OptionValueIsArray, "", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(option.IsArray(), qt.IsTrue)
+	c.Assert(option.GetDefault(), qt.DeepEquals, []string{})
+
+	option, err = NewInputOption("ansi", "", OptionValueNegatable, "", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(option.IsNegatable(), qt.IsTrue)
+	c.Assert(option.GetNegation(), qt.Equals, "no-ansi")
+	c.Assert(option.GetDefault(), qt.IsNil)
+
+	option, err = NewInputOption("interaction", "", OptionValueNone|OptionValueNegatable, "", true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(option.GetDefault(), qt.Equals, true)
+}
+
+func TestNewInputOption_Errors(t *testing.T) {
+	type cs struct {
+		Name         string
+		OptionName   string
+		Shortcut     string
+		Mode         int
+		DefaultValue interface{}
+		Error        string
+	}
+	cases := []cs{
+		{Name: "empty name", OptionName: "--", Error: "An option name cannot be empty."},
+		{Name: "empty shortcut", OptionName: "foo", Shortcut: "-|-", Error: "An option shortcut cannot be empty."},
+		{Name: "unknown mode", OptionName: "foo", Mode: 32, Error: `Option mode "32" is not valid.`},
+		{Name: "array without value", OptionName: "foo", Mode: OptionValueIsArray, Error: "Impossible to have an option mode OptionValueIsArray if the option does not accept a value."},
+		{Name: "negatable with value", OptionName: "foo", Mode: OptionValueRequired | OptionValueNegatable, Error: "Impossible to have an option mode OptionValueNegatable if the option also accepts a value."},
+		{Name: "default without value", OptionName: "foo", Mode: OptionValueNone, DefaultValue: "bar", Error: "Cannot set a default value when using OptionValueNone mode."},
+		{Name: "default of array option", OptionName: "foo", Mode: OptionValueOptional | OptionValueIsArray, DefaultValue: "bar", Error: "A default value for an array option must be an array."},
+		{Name: "default of negatable option", OptionName: "foo", Mode: OptionValueNegatable, DefaultValue: "bar", Error: "A default value for a negatable option must be a boolean."},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := NewInputOption(testCase.OptionName, testCase.Shortcut, testCase.Mode, "", testCase.DefaultValue)
+			c.Assert(err, qt.ErrorMatches, testCase.Error)
+		})
+	}
+}
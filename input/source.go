@@ -0,0 +1,203 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Sources of an option value, an option not given in the input falls back
+// on its environment variable, then on the config, then on its default value
+const (
+	SourceDefault ValueSource = iota
+	SourceConfig
+	SourceEnv
+	SourceInput
+)
+
+// ValueSource tells where the value of an option comes from
+type ValueSource int
+
+// String returns the name of the source
+func (s ValueSource) String() string {
+	switch s {
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	case SourceInput:
+		return "input"
+	}
+	return "default"
+}
+
+// SetConfig sets the option values keyed by option name used as fallback, like the ones read by LoadConfig.
+// The config is applied when the input is bound to a definition, keys not matching an option are ignored.
+func (i *Input) SetConfig(config map[string]interface{}) {
+	i.config = config
+}
+
+// GetConfig returns the option values used as fallback
+func (i *Input) GetConfig() map[string]interface{} {
+	return i.config
+}
+
+// GetOptionSource returns where the value of the option with given name comes from
+func (i *Input) GetOptionSource(name string) (ValueSource, error) {
+	if i.definition.HasNegation(name) {
+		name, _ = i.definition.NegationToName(name)
+	}
+	if !i.definition.HasOption(name) {
+		return SourceDefault, fmt.Errorf(`The "%s" option does not exist.`, name)
+	}
+
+	return i.sources[name], nil
+}
+
+// GetOptionSources returns where the option values come from, keyed by option name
+func (i *Input) GetOptionSources() map[string]ValueSource {
+	sources := make(map[string]ValueSource)
+	for _, option := range i.definition.GetOptions() {
+		sources[option.GetName()] = i.sources[option.GetName()]
+	}
+	return sources
+}
+
+// resolve sets the options not given in the input from their environment variable or from the config.
+// It is called once the input is parsed.
+func (i *Input) resolve() error {
+	for name := range i.options {
+		i.sources[name] = SourceInput
+	}
+
+	for _, option := range i.definition.GetOptions() {
+		name := option.GetName()
+		if _, ok := i.options[name]; ok {
+			continue
+		}
+
+		// an empty variable of an option without value, like VERBOSE=, is considered unset
+		if env := option.GetEnvVar(); "" != env {
+			if value, ok := os.LookupEnv(env); ok && ("" != strings.TrimSpace(value) || option.AcceptValue()) {
+				converted, err := convertOptionValue(option, value)
+				if nil != err {
+					return fmt.Errorf(`The value "%s" of the "%s" environment variable is not valid for the "--%s" option: %s`, value, env, name, err)
+				}
+				i.options[name] = converted
+				i.sources[name] = SourceEnv
+				continue
+			}
+		}
+
+		value, ok := i.configValue(option)
+		if !ok {
+			continue
+		}
+		converted, err := convertOptionValue(option, value)
+		if nil != err {
+			return fmt.Errorf(`The value "%v" of the config is not valid for the "--%s" option: %s`, value, name, err)
+		}
+		i.options[name] = converted
+		i.sources[name] = SourceConfig
+	}
+
+	return nil
+}
+
+// configValue returns the config value of the option, the negation
+// of a negatable option like "no-ansi" gives the negated value
+func (i *Input) configValue(option *InputOption) (interface{}, bool) {
+	if value, ok := i.config[option.GetName()]; ok && nil != value {
+		return value, true
+	}
+
+	if !option.IsNegatable() {
+		return nil, false
+	}
+	value, ok := i.config[option.GetNegation()]
+	if !ok || nil == value {
+		return nil, false
+	}
+	if s, ok := value.(string); ok {
+		if b, err := strconv.ParseBool(s); nil == err {
+			value = b
+		}
+	}
+	if b, ok := value.(bool); ok {
+		return !b, true
+	}
+	return value, true
+}
+
+// convertOptionValue converts a value from the environment or the config to the type of the option value:
+// a bool for an option without value, a []string for an array option and a string otherwise.
+// A string given to an array option is a comma separated list.
+func convertOptionValue(option *InputOption, value interface{}) (interface{}, error) {
+	switch {
+	case !option.AcceptValue():
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		if s, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(strings.TrimSpace(s)); nil == err {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("a boolean is expected")
+	case option.IsArray():
+		var values []string
+		switch typed := value.(type) {
+		case string:
+			for _, s := range strings.Split(typed, ",") {
+				if s = strings.TrimSpace(s); "" != s {
+					values = append(values, s)
+				}
+			}
+		case []string:
+			values = typed
+		case []interface{}:
+			for _, v := range typed {
+				s, ok := scalarString(v)
+				if !ok {
+					return nil, fmt.Errorf("a list of scalar values is expected")
+				}
+				values = append(values, s)
+			}
+		default:
+			return nil, fmt.Errorf("a list is expected")
+		}
+		if nil == values {
+			values = []string{}
+		}
+		return values, nil
+	}
+
+	s, ok := scalarString(value)
+	if !ok {
+		return nil, fmt.Errorf("a scalar value is expected")
+	}
+	return s, nil
+}
+
+// scalarString formats a string, number or boolean value of any integer, float or string kind
+func scalarString(value interface{}) (string, bool) {
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), true
+	}
+	return "", false
+}
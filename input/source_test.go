@@ -0,0 +1,209 @@
+package input
+
+import (
+	qt "github.com/frankban/quicktest"
+	"testing"
+)
+
+func createFallbackDefinition(t *testing.T) *InputDefinition {
+	t.Helper()
+	d := createDefinition(t)
+
+	envVars := map[string]string{
+		"verbose": "APP_VERBOSE",
+		"env":     "APP_ENV",
+		"tag":     "APP_TAGS",
+		"ansi":    "APP_ANSI",
+	}
+	for name, env := range envVars {
+		option, err := d.GetOption(name)
+		if nil != err {
+			t.Fatal(err)
+		}
+		option.SetEnvVar(env)
+	}
+
+	return d
+}
+
+func TestInput_Fallbacks(t *testing.T) {
+	type cs struct {
+		Name    string
+		Argv    []string
+		Env     map[string]string
+		Config  map[string]interface{}
+		Options map[string]interface{}
+		Sources map[string]ValueSource
+	}
+	cases := []cs{
+		{
+			Name:    "defaults",
+			Argv:    []string{"john"},
+			Options: map[string]interface{}{"env": "dev", "verbose": false, "tag": []string{}},
+			Sources: map[string]ValueSource{"env": SourceDefault, "verbose": SourceDefault, "tag": SourceDefault},
+		},
+		{
+			Name:    "config",
+			Argv:    []string{"john"},
+			Config:  map[string]interface{}{"env": "staging", "verbose": true, "tag": []interface{}{"a", float64(2)}, "color": float64(256)},
+			Options: map[string]interface{}{"env": "staging", "verbose": true, "tag": []string{"a", "2"}, "color": "256"},
+			Sources: map[string]ValueSource{"env": SourceConfig, "verbose": SourceConfig, "tag": SourceConfig, "color": SourceConfig},
+		},
+		{
+			Name:    "config strings",
+			Argv:    []string{"john"},
+			Config:  map[string]interface{}{"verbose": "TRUE", "force": "1", "tag": "a, b"},
+			Options: map[string]interface{}{"verbose": true, "force": true, "tag": []string{"a", "b"}},
+		},
+		{
+			Name:    "config negation",
+			Argv:    []string{"john"},
+			Config:  map[string]interface{}{"no-ansi": "true"},
+			Options: map[string]interface{}{"ansi": false},
+			Sources: map[string]ValueSource{"ansi": SourceConfig},
+		},
+		{
+			Name:    "config null",
+			Argv:    []string{"john"},
+			Config:  map[string]interface{}{"env": nil, "unknown": "x"},
+			Options: map[string]interface{}{"env": "dev"},
+			Sources: map[string]ValueSource{"env": SourceDefault},
+		},
+		{
+			Name:    "env over config",
+			Argv:    []string{"john"},
+			Env:     map[string]string{"APP_ENV": "prod", "APP_VERBOSE": "true", "APP_TAGS": "a,,b", "APP_ANSI": "0"},
+			Config:  map[string]interface{}{"env": "staging", "verbose": false, "tag": "c"},
+			Options: map[string]interface{}{"env": "prod", "verbose": true, "tag": []string{"a", "b"}, "ansi": false},
+			Sources: map[string]ValueSource{"env": SourceEnv, "verbose": SourceEnv, "tag": SourceEnv, "ansi": SourceEnv},
+		},
+		{
+			Name:    "empty env overrides config",
+			Argv:    []string{"john"},
+			Env:     map[string]string{"APP_ENV": "", "APP_TAGS": ""},
+			Config:  map[string]interface{}{"env": "staging", "tag": "a"},
+			Options: map[string]interface{}{"env": "", "tag": []string{}},
+			Sources: map[string]ValueSource{"env": SourceEnv, "tag": SourceEnv},
+		},
+		{
+			Name:    "empty env of options without value is unset",
+			Argv:    []string{"john"},
+			Env:     map[string]string{"APP_VERBOSE": "", "APP_ANSI": " "},
+			Config:  map[string]interface{}{"verbose": true},
+			Options: map[string]interface{}{"verbose": true, "ansi": nil},
+			Sources: map[string]ValueSource{"verbose": SourceConfig, "ansi": SourceDefault},
+		},
+		{
+			Name:    "input over env",
+			Argv:    []string{"john", "--env=test", "-t", "x", "--no-ansi"},
+			Env:     map[string]string{"APP_ENV": "prod", "APP_TAGS": "a", "APP_ANSI": "1"},
+			Config:  map[string]interface{}{"env": "staging"},
+			Options: map[string]interface{}{"env": "test", "tag": []string{"x"}, "ansi": false},
+			Sources: map[string]ValueSource{"env": SourceInput, "tag": SourceInput, "ansi": SourceInput},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			for name, value := range testCase.Env {
+				t.Setenv(name, value)
+			}
+
+			input := NewArgvInput(append([]string{"cli"}, testCase.Argv...))
+			input.SetConfig(testCase.Config)
+			c.Assert(input.Bind(createFallbackDefinition(t)), qt.IsNil)
+
+			options := input.GetOptions()
+			for name, expected := range testCase.Options {
+				c.Assert(options[name], qt.DeepEquals, expected, qt.Commentf("option %s", name))
+			}
+			for name, expected := range testCase.Sources {
+				source, err := input.GetOptionSource(name)
+				c.Assert(err, qt.IsNil)
+				c.Assert(source, qt.Equals, expected, qt.Commentf("option %s", name))
+			}
+		})
+	}
+}
+
+func TestInput_FallbackErrors(t *testing.T) {
+	type cs struct {
+		Name   string
+		Env    map[string]string
+		Config map[string]interface{}
+		Error  string
+	}
+	cases := []cs{
+		{
+			Name:  "env not a boolean",
+			Env:   map[string]string{"APP_VERBOSE": "yes"},
+			Error: `The value "yes" of the "APP_VERBOSE" environment variable is not valid for the "--verbose" option: a boolean is expected`,
+		},
+		{
+			Name:   "config not a boolean",
+			Config: map[string]interface{}{"force": float64(1)},
+			Error:  `The value "1" of the config is not valid for the "--force" option: a boolean is expected`,
+		},
+		{
+			Name:   "config not a list",
+			Config: map[string]interface{}{"tag": true},
+			Error:  `The value "true" of the config is not valid for the "--tag" option: a list is expected`,
+		},
+		{
+			Name:   "config list of objects",
+			Config: map[string]interface{}{"tag": []interface{}{map[string]interface{}{}}},
+			Error:  `The value .* of the config is not valid for the "--tag" option: a list of scalar values is expected`,
+		},
+		{
+			Name:   "config not a scalar",
+			Config: map[string]interface{}{"env": []interface{}{"a"}},
+			Error:  `The value .* of the config is not valid for the "--env" option: a scalar value is expected`,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			for name, value := range testCase.Env {
+				t.Setenv(name, value)
+			}
+
+			input := NewArgvInput([]string{"cli", "john"})
+			input.SetConfig(testCase.Config)
+			c.Assert(input.Bind(createFallbackDefinition(t)), qt.ErrorMatches, testCase.Error)
+		})
+	}
+}
+
+func TestInput_OptionSources(t *testing.T) {
+	c := qt.New(t)
+	t.Setenv("APP_ENV", "prod")
+
+	input := NewArrayInput(map[string]interface{}{"name": "john", "--force": true})
+	input.SetConfig(map[string]interface{}{"color": "never"})
+	c.Assert(input.Bind(createFallbackDefinition(t)), qt.IsNil)
+	c.Assert(input.SetOption("no-ansi", true), qt.IsNil)
+
+	c.Assert(input.GetOptionSources(), qt.DeepEquals, map[string]ValueSource{
+		"verbose": SourceDefault,
+		"force":   SourceInput,
+		"env":     SourceEnv,
+		"color":   SourceConfig,
+		"tag":     SourceDefault,
+		"ansi":    SourceInput,
+	})
+
+	source, err := input.GetOptionSource("no-ansi")
+	c.Assert(err, qt.IsNil)
+	c.Assert(source.String(), qt.Equals, "input")
+	c.Assert(SourceEnv.String(), qt.Equals, "env")
+	c.Assert(SourceConfig.String(), qt.Equals, "config")
+	c.Assert(SourceDefault.String(), qt.Equals, "default")
+
+	_, err = input.GetOptionSource("foo")
+	c.Assert(err, qt.ErrorMatches, `The "foo" option does not exist.`)
+
+	option, _ := input.GetDefinition().GetOption("env")
+	c.Assert(option.GetEnvVar(), qt.Equals, "APP_ENV")
+}
@@ -0,0 +1,118 @@
+package input
+
+import (
+	"strings"
+	"unicode"
+)
+
+// StringInput represents an input given as a single command line string, like "deploy --env=prod",
+// the string is split into tokens with Tokenize and parsed like an ArgvInput.
+type StringInput struct {
+	*ArgvInput
+}
+
+// NewStringInput creates new StringInput object from a command line string, without program name
+func NewStringInput(input string) (*StringInput, error) {
+	tokens, err := Tokenize(input)
+	if nil != err {
+		return nil, err
+	}
+
+	return &StringInput{
+		ArgvInput: &ArgvInput{
+			tokens: tokens,
+			Input:  NewInput(),
+		},
+	}, nil
+}
+
+// Tokenize splits a command line string into tokens following the shell quoting rules.
+// Tokens are separated by white spaces, single quotes keep every character as is,
+// double quotes keep every character but backslash escaped `"`, `\`, `$` and "`",
+// and outside quotes a backslash escapes the next character.
+// Adjacent quoted and unquoted segments form a single token, like --name="John Doe".
+func Tokenize(input string) ([]string, error) {
+	var tokens []string
+	var token strings.Builder
+	inToken := false
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		case '\'' == r:
+			end := i + 1
+			for end < len(runes) && '\'' != runes[end] {
+				end++
+			}
+			if end == len(runes) {
+				return nil, tokenizeError(runes, i)
+			}
+			token.WriteString(string(runes[i+1 : end]))
+			inToken = true
+			i = end
+		case '"' == r:
+			end, err := readDoubleQuoted(runes, i, &token)
+			if nil != err {
+				return nil, err
+			}
+			inToken = true
+			i = end
+		case '\\' == r:
+			if i+1 == len(runes) {
+				return nil, tokenizeError(runes, i)
+			}
+			i++
+			// an escaped newline continues the line
+			if '\n' != runes[i] {
+				token.WriteRune(runes[i])
+				inToken = true
+			}
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens, nil
+}
+
+// readDoubleQuoted writes the content of the double quoted segment starting at given position
+// into the token and returns the position of the closing quote
+func readDoubleQuoted(runes []rune, start int, token *strings.Builder) (int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case '"' == r:
+			return i, nil
+		case '\\' == r && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]):
+			i++
+			if '\n' != runes[i] {
+				token.WriteRune(runes[i])
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+
+	return 0, tokenizeError(runes, start)
+}
+
+// tokenizeError returns the error of the offending character at given position
+func tokenizeError(runes []rune, position int) error {
+	end := position + 10
+	if end > len(runes) {
+		end = len(runes)
+	}
+	return &TokenizeError{Near: string(runes[position:end])}
+}
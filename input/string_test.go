@@ -0,0 +1,102 @@
+package input
+
+import (
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type cs struct {
+		Name     string
+		Input    string
+		Expected []string
+	}
+	cases := []cs{
+		{Name: "empty", Input: "", Expected: nil},
+		{Name: "spaces only", Input: " \t\n ", Expected: nil},
+		{Name: "words", Input: "foo bar", Expected: []string{"foo", "bar"}},
+		{Name: "several spaces", Input: "  foo \t bar\n", Expected: []string{"foo", "bar"}},
+		{Name: "single quotes", Input: `'foo bar' baz`, Expected: []string{"foo bar", "baz"}},
+		{Name: "single quotes keep backslashes", Input: `'a\"b\\c'`, Expected: []string{`a\"b\\c`}},
+		{Name: "double quotes", Input: `"foo bar" baz`, Expected: []string{"foo bar", "baz"}},
+		{Name: "double quotes with escapes", Input: `"say \"hi\" \\ \$HOME \` + "`" + `"`, Expected: []string{`say "hi" \ $HOME ` + "`"}},
+		{Name: "double quotes keep other backslashes", Input: `"a\nb"`, Expected: []string{`a\nb`}},
+		{Name: "single quote in double quotes", Input: `"it's"`, Expected: []string{"it's"}},
+		{Name: "double quote in single quotes", Input: `'say "hi"'`, Expected: []string{`say "hi"`}},
+		{Name: "empty quotes", Input: `foo "" ''`, Expected: []string{"foo", "", ""}},
+		{Name: "adjacent segments", Input: `--name="John Doe"'s' x`, Expected: []string{"--name=John Does", "x"}},
+		{Name: "quoted option value", Input: `--env 'prod eu'`, Expected: []string{"--env", "prod eu"}},
+		{Name: "escaped space", Input: `foo\ bar baz`, Expected: []string{"foo bar", "baz"}},
+		{Name: "escaped quote", Input: `it\'s`, Expected: []string{"it's"}},
+		{Name: "escaped backslash", Input: `a\\b`, Expected: []string{`a\b`}},
+		{Name: "line continuation", Input: "foo \\\nbar", Expected: []string{"foo", "bar"}},
+		{Name: "line continuation in double quotes", Input: "\"foo\\\nbar\"", Expected: []string{"foobar"}},
+		{Name: "unicode", Input: `grüß "日本 語"`, Expected: []string{"grüß", "日本 語"}},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			tokens, err := Tokenize(testCase.Input)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tokens, qt.DeepEquals, testCase.Expected)
+		})
+	}
+}
+
+func TestTokenize_Errors(t *testing.T) {
+	type cs struct {
+		Name  string
+		Input string
+		Error string
+	}
+	cases := []cs{
+		{Name: "unterminated single quote", Input: `foo 'bar baz`, Error: `Unable to parse input near "'bar baz".`},
+		{Name: "unterminated double quote", Input: `foo "bar \" baz qux quux`, Error: `Unable to parse input near ""bar \\" ba".`},
+		{Name: "trailing backslash", Input: `foo \`, Error: `Unable to parse input near "\\".`},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := Tokenize(testCase.Input)
+			c.Assert(err, qt.ErrorMatches, testCase.Error)
+
+			var tokenizeError *TokenizeError
+			c.Assert(errors.As(err, &tokenizeError), qt.IsTrue)
+		})
+	}
+}
+
+func TestStringInput(t *testing.T) {
+	c := qt.New(t)
+
+	input, err := NewStringInput(`john "a file.txt" --env='prod eu' -vt"x y" --no-ansi`)
+	c.Assert(err, qt.IsNil)
+	c.Assert(input.GetTokens(), qt.DeepEquals, []string{"john", "a file.txt", "--env=prod eu", "-vtx y", "--no-ansi"})
+	c.Assert(input.Bind(createDefinition(t)), qt.IsNil)
+	c.Assert(input.Validate(), qt.IsNil)
+
+	c.Assert(input.GetArguments(), qt.DeepEquals, map[string]interface{}{
+		"name":  "john",
+		"files": []string{"a file.txt"},
+	})
+	options := input.GetOptions()
+	c.Assert(options["env"], qt.Equals, "prod eu")
+	c.Assert(options["verbose"], qt.Equals, true)
+	c.Assert(options["tag"], qt.DeepEquals, []string{"x y"})
+	c.Assert(options["ansi"], qt.Equals, false)
+
+	_, err = NewStringInput(`john "unterminated`)
+	c.Assert(err, qt.ErrorMatches, `Unable to parse input near ""untermina".`)
+
+	input, err = NewStringInput("john --foo")
+	c.Assert(err, qt.IsNil)
+	c.Assert(input.Bind(createDefinition(t)), qt.ErrorMatches, `The "--foo" option does not exist.`)
+}
+
+func TestStringInput_ImplementsIInput(t *testing.T) {
+	input, _ := NewStringInput("")
+	var _ IInput = input
+}
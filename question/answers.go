@@ -0,0 +1,144 @@
+package question
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AnswerProvider provides recorded answers of questions by their name
+type AnswerProvider interface {
+	Answer(name string) (answer string, ok bool)
+}
+
+// AnswerRecorder records answers of questions by their name
+type AnswerRecorder interface {
+	Record(name string, answer string)
+}
+
+// AnswerFile holds answers of questions keyed by their name,
+// it can be loaded from and saved into a JSON file
+type AnswerFile struct {
+	answers map[string]string
+}
+
+// NewAnswerFile creates new empty AnswerFile object
+func NewAnswerFile() *AnswerFile {
+	return &AnswerFile{
+		answers: make(map[string]string),
+	}
+}
+
+// LoadAnswerFile loads answers from a JSON file containing an object keyed by question name.
+// Numbers are kept as written and booleans are converted to strings, arrays are joined with comma
+// so they can answer multiselect questions.
+func LoadAnswerFile(path string) (*AnswerFile, error) {
+	contents, err := os.ReadFile(path)
+	if nil != err {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+	err = decoder.Decode(&values)
+	if nil == err {
+		if _, token := decoder.Token(); io.EOF != token {
+			err = errors.New("unexpected data after the answers")
+		}
+	}
+	if nil != err {
+		return nil, fmt.Errorf(`The answer file "%s" is invalid: %s`, path, err)
+	}
+
+	af := NewAnswerFile()
+	for name, value := range values {
+		af.answers[name] = answerToString(value)
+	}
+
+	return af, nil
+}
+
+// Answer returns the answer of the question with given name
+func (af *AnswerFile) Answer(name string) (string, bool) {
+	answer, ok := af.answers[name]
+	return answer, ok
+}
+
+// Record sets the answer of the question with given name
+func (af *AnswerFile) Record(name string, answer string) {
+	af.answers[name] = answer
+}
+
+// Names returns the sorted names of the answered questions
+func (af *AnswerFile) Names() []string {
+	names := make([]string, 0, len(af.answers))
+	for name := range af.answers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Save writes the answers into a JSON file
+func (af *AnswerFile) Save(path string) error {
+	contents, err := json.MarshalIndent(af.answers, "", "  ")
+	if nil != err {
+		return err
+	}
+
+	return os.WriteFile(path, append(contents, '\n'), 0o600)
+}
+
+// answerToString converts a decoded JSON value into an answer
+func answerToString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case json.Number:
+		return typed.String()
+	case []interface{}:
+		var answers []string
+		for _, v := range typed {
+			answers = append(answers, answerToString(v))
+		}
+		return strings.Join(answers, ",")
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// EnvAnswers provides answers from environment variables.
+// The variable name is the prefix followed by the question name in upper case,
+// with every character other than letters and digits replaced by underscore,
+// e.g. the "db.host" question is answered by SETUP_DB_HOST with "SETUP_" prefix.
+type EnvAnswers struct {
+	Prefix string
+}
+
+// envNameRegex matches characters not allowed in environment variable names
+var envNameRegex = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// NewEnvAnswers creates new EnvAnswers object with given variable name prefix
+func NewEnvAnswers(prefix string) *EnvAnswers {
+	return &EnvAnswers{Prefix: prefix}
+}
+
+// Answer returns the answer of the question with given name
+func (ea *EnvAnswers) Answer(name string) (string, bool) {
+	return os.LookupEnv(ea.VariableName(name))
+}
+
+// VariableName returns the environment variable name answering the question with given name
+func (ea *EnvAnswers) VariableName(name string) string {
+	return ea.Prefix + envNameRegex.ReplaceAllString(strings.ToUpper(name), "_")
+}
@@ -0,0 +1,161 @@
+package question
+
+import (
+	qt "github.com/frankban/quicktest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadAnswerFile(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "answers.json")
+	contents := `{"name": "John", "port": 8080, "id": 12345678, "big": 12345678901234567890, "ratio": 1.50, "confirm": true, "envs": ["dev", 2], "empty": null}`
+	c.Assert(os.WriteFile(path, []byte(contents), 0o600), qt.IsNil)
+
+	af, err := LoadAnswerFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(af.Names(), qt.DeepEquals, []string{"big", "confirm", "empty", "envs", "id", "name", "port", "ratio"})
+
+	for name, expected := range map[string]string{
+		"name":    "John",
+		"port":    "8080",
+		"id":      "12345678",
+		"big":     "12345678901234567890",
+		"ratio":   "1.50",
+		"confirm": "true",
+		"envs":    "dev,2",
+		"empty":   "",
+	} {
+		answer, ok := af.Answer(name)
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(answer, qt.Equals, expected)
+	}
+
+	_, ok := af.Answer("unknown")
+	c.Assert(ok, qt.IsFalse)
+
+	// large numbers are replayed as written
+	o, _ := createOutput()
+	h := NewHelper()
+	h.SetAnswerProvider(af)
+	q := NewIntQuestion("Id?")
+	q.SetName("id")
+	answer, err := h.Ask(strings.NewReader(""), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, 12345678)
+}
+
+func TestLoadAnswerFile_Invalid(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "answers.json")
+	c.Assert(os.WriteFile(path, []byte(`["foo"]`), 0o600), qt.IsNil)
+
+	_, err := LoadAnswerFile(path)
+	c.Assert(err, qt.ErrorMatches, `The answer file ".*" is invalid: .*`)
+
+	c.Assert(os.WriteFile(path, []byte(`{"name": "John"} {}`), 0o600), qt.IsNil)
+	_, err = LoadAnswerFile(path)
+	c.Assert(err, qt.ErrorMatches, `The answer file ".*" is invalid: unexpected data after the answers`)
+
+	_, err = LoadAnswerFile(filepath.Join(t.TempDir(), "missing.json"))
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+}
+
+func TestAnswerFile_Save(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "answers.json")
+	af := NewAnswerFile()
+	af.Record("name", "John")
+	af.Record("envs", "dev,prod")
+
+	c.Assert(af.Save(path), qt.IsNil)
+	contents, err := os.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(contents), qt.Equals, "{\n  \"envs\": \"dev,prod\",\n  \"name\": \"John\"\n}\n")
+
+	loaded, err := LoadAnswerFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(loaded.Names(), qt.DeepEquals, af.Names())
+}
+
+func TestEnvAnswers(t *testing.T) {
+	c := qt.New(t)
+	t.Setenv("SETUP_DB_HOST", "localhost")
+	ea := NewEnvAnswers("SETUP_")
+
+	c.Assert(ea.VariableName("db.host"), qt.Equals, "SETUP_DB_HOST")
+	c.Assert(ea.VariableName("cluster-name"), qt.Equals, "SETUP_CLUSTER_NAME")
+
+	answer, ok := ea.Answer("db.host")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(answer, qt.Equals, "localhost")
+
+	_, ok = ea.Answer("db.port")
+	c.Assert(ok, qt.IsFalse)
+}
+
+func TestHelper_AskWithAnswerProvider(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	af := NewAnswerFile()
+	af.Record("port", "8080")
+	af.Record("env", "staging")
+	h := NewHelper()
+	h.SetAnswerProvider(af)
+	c.Assert(h.GetAnswerProvider(), qt.Equals, af)
+
+	port := NewIntQuestion("Port?")
+	port.SetName("port")
+	answer, err := h.Ask(strings.NewReader(""), o, port)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, 8080)
+	c.Assert(buffer.String(), qt.Equals, "")
+
+	// the validator still runs on provided answers
+	env := NewChoiceQuestion("Environment?", []string{"dev", "prod"})
+	env.SetName("env")
+	_, err = h.Ask(strings.NewReader("dev\n"), o, env)
+	c.Assert(err, qt.ErrorMatches, `Value "staging" is invalid`)
+
+	// questions without provided answer are asked
+	name := NewQuestion("Name?")
+	name.SetName("name")
+	answer, err = h.Ask(strings.NewReader("John\n"), o, name)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "John")
+}
+
+func TestHelper_AskWithAnswerRecorder(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	af := NewAnswerFile()
+	h := NewHelper()
+	h.SetAnswerRecorder(af)
+	c.Assert(h.GetAnswerRecorder(), qt.Equals, af)
+	input := strings.NewReader("John\ns3cr3t\n1\nanonymous\n")
+
+	name := NewQuestion("Name?")
+	name.SetName("name")
+	_, err := h.Ask(input, o, name)
+	c.Assert(err, qt.IsNil)
+
+	token := NewQuestion("Token?")
+	token.SetName("token")
+	_ = token.SetHidden(true)
+	_, err = h.Ask(input, o, token)
+	c.Assert(err, qt.IsNil)
+
+	env := NewChoiceQuestion("Environment?", []string{"dev", "prod"})
+	env.SetName("env")
+	_, err = h.Ask(input, o, env)
+	c.Assert(err, qt.IsNil)
+
+	_, err = h.Ask(input, o, NewQuestion("Unnamed?"))
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(af.Names(), qt.DeepEquals, []string{"env", "name"})
+	answer, _ := af.Answer("env")
+	c.Assert(answer, qt.Equals, "1")
+}
@@ -2,28 +2,62 @@ package question
 
 import (
 	"errors"
-	"expvar"
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+// Choice represents a single choice of a ChoiceQuestion.
+// The choice can be selected by its key, or by its label when no key matches,
+// and the validator of the question returns its value.
+type Choice struct {
+	Key         string
+	Label       string
+	Value       interface{}
+	Description string
+	Disabled    bool
+}
+
+// describe returns the label of the choice with its description and state
+func (c Choice) describe() string {
+	text := c.Label
+	if "" != c.Description {
+		text = fmt.Sprintf("%s - %s", text, c.Description)
+	}
+	if c.Disabled {
+		text += " (disabled)"
+	}
+	return text
+}
+
+// maxSuggestions is the maximum number of choices suggested when an answer does not match
+const maxSuggestions = 3
+
+// ChoiceQuestion represents a question answered by selecting one or more choices
 type ChoiceQuestion struct {
-	choices      interface{}
+	choices      []Choice
 	multiselect  bool
+	minSelected  int
+	maxSelected  int
+	fuzzy        bool
 	prompt       string
 	errorMessage string
 	*Question
 }
 
+// NewChoiceQuestion creates new ChoiceQuestion object, see SetChoices for supported choices.
+// It panics when the choices are not supported.
 func NewChoiceQuestion(question string, choices interface{}) *ChoiceQuestion {
 	q := &ChoiceQuestion{
 		Question: NewQuestion(question),
 	}
 
-	q.SetChoices(choices)
+	if err := q.SetChoices(choices); nil != err {
+		panic(err)
+	}
 	q.SetMultiSelect(false)
 	q.SetPrompt(" > ")
 	q.SetErrorMessage(`Value "%s" is invalid`)
@@ -33,11 +67,77 @@ func NewChoiceQuestion(question string, choices interface{}) *ChoiceQuestion {
 	return q
 }
 
-func (cq *ChoiceQuestion) SetChoices(choices interface{}) {
-	cq.choices = choices
+// SetChoices sets the choices of this question in display order. Supported choices are:
+// * []Choice: used as is
+// * any other slice or array, like []string or []int: keyed by their index, the value is the element
+// * any map, like map[string]interface{}: sorted by key, labelled by the map value, the value is the key
+// A nil value means no choice, other types are rejected.
+func (cq *ChoiceQuestion) SetChoices(choices interface{}) error {
+	if typed, ok := choices.([]Choice); ok {
+		cq.choices = typed
+		return nil
+	}
+
+	var list []Choice
+	rv := reflect.ValueOf(choices)
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			value := rv.Index(i).Interface()
+			list = append(list, Choice{Key: strconv.Itoa(i), Label: fmt.Sprintf("%v", value), Value: value})
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return lessMapKey(keys[i], keys[j])
+		})
+		for _, key := range keys {
+			list = append(list, Choice{
+				Key:   fmt.Sprintf("%v", key.Interface()),
+				Label: fmt.Sprintf("%v", rv.MapIndex(key).Interface()),
+				Value: key.Interface(),
+			})
+		}
+	default:
+		return fmt.Errorf("Choices of type %T are not supported", choices)
+	}
+
+	cq.choices = list
+	return nil
+}
+
+// lessMapKey orders map keys, numerically for numeric keys and by their string form otherwise
+func lessMapKey(a reflect.Value, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	}
+	return fmt.Sprintf("%v", a.Interface()) < fmt.Sprintf("%v", b.Interface())
 }
 
-func (cq *ChoiceQuestion) GetChoices() interface{} {
+// SetDefault sets the default answer, a key or label of a choice.
+// A slice like []string{"dev", "prod"} selects several choices of a multiselect question,
+// it is stored as the comma separated list the user would type.
+func (cq *ChoiceQuestion) SetDefault(value interface{}) {
+	rv := reflect.ValueOf(value)
+	if reflect.Slice == rv.Kind() || reflect.Array == rv.Kind() {
+		selected := make([]string, rv.Len())
+		for i := range selected {
+			selected[i] = fmt.Sprintf("%v", rv.Index(i).Interface())
+		}
+		value = strings.Join(selected, ",")
+	}
+	cq.Question.SetDefault(value)
+}
+
+// GetChoices returns the choices of this question in display order
+func (cq *ChoiceQuestion) GetChoices() []Choice {
 	return cq.choices
 }
 
@@ -49,6 +149,40 @@ func (cq *ChoiceQuestion) GetMultiSelect() bool {
 	return cq.multiselect
 }
 
+// SetMinSelected sets the minimum number of choices selected in a multiselect answer,
+// zero means no minimum
+func (cq *ChoiceQuestion) SetMinSelected(count int) {
+	cq.minSelected = count
+}
+
+// GetMinSelected returns the minimum number of choices selected in a multiselect answer
+func (cq *ChoiceQuestion) GetMinSelected() int {
+	return cq.minSelected
+}
+
+// SetMaxSelected sets the maximum number of choices selected in a multiselect answer,
+// zero means no maximum
+func (cq *ChoiceQuestion) SetMaxSelected(count int) {
+	cq.maxSelected = count
+}
+
+// GetMaxSelected returns the maximum number of choices selected in a multiselect answer
+func (cq *ChoiceQuestion) GetMaxSelected() int {
+	return cq.maxSelected
+}
+
+// SetFuzzyMatching sets whether answers may select a choice by an unambiguous,
+// case-insensitive prefix of its key or label.
+// When enabled, errors of unknown answers suggest the closest choices.
+func (cq *ChoiceQuestion) SetFuzzyMatching(fuzzy bool) {
+	cq.fuzzy = fuzzy
+}
+
+// IsFuzzyMatching returns whether fuzzy matching of answers is enabled
+func (cq *ChoiceQuestion) IsFuzzyMatching() bool {
+	return cq.fuzzy
+}
+
 func (cq *ChoiceQuestion) SetPrompt(prompt string) {
 	cq.prompt = prompt
 }
@@ -89,76 +223,166 @@ func (cq *ChoiceQuestion) getDefaultValidator() func(input string) (v interface{
 			sChoices = trimmed
 		}
 
-		var multiSelectChoices []string
-		var currentChoice string
+		var selection []interface{}
 		for _, choiceValue := range sChoices {
-			var results []string
-			var result string
-			cType := reflect.TypeOf(cq.choices)
-			if reflect.Map == cType.Kind() {
-				for key, val := range cq.choices.(map[string]interface{}) {
-					converted := val
-
-					if _, ok := val.(expvar.Var); ok {
-						converted = val.(expvar.Var).String()
-					}
-
-					if key == choiceValue {
-						results = append(results, key)
-						result = choiceValue
-					} else if choiceValue == converted {
-						results = append(results, key)
-						result = key
-					}
-				}
-			} else if reflect.Slice == cType.Kind() {
-				choicesType := reflect.TypeOf(cq.choices).Elem().String()
-				if "string" == choicesType {
-					for key, choice := range cq.choices.([]string) {
-						strChoice := fmt.Sprintf("%v", choice)
-
-						if strChoice == choiceValue {
-							results = append(results, strChoice)
-							result = strChoice
-						} else if kToStr := strconv.Itoa(key); kToStr == choiceValue {
-							results = append(results, strChoice)
-							result = strChoice
-						}
-					}
-				} else if "interface {}" == choicesType {
-					for key, choice := range cq.choices.([]interface{}) {
-						strChoice := fmt.Sprintf("%v", choice)
-
-						if strChoice == choiceValue {
-							results = append(results, strChoice)
-							result = strChoice
-						} else if kToStr := strconv.Itoa(key); kToStr == choiceValue {
-							results = append(results, strChoice)
-							result = strChoice
-						}
-					}
-				}
+			choice, err := cq.findChoice(choiceValue)
+			if nil != err {
+				return nil, err
 			}
+			selection = append(selection, choice.Value)
+		}
 
-			if len(results) > 1 {
-				errMsg := fmt.Sprintf(
-					`The provided answer is ambigous. Value should be one of "%s"`,
-					strings.Join(results, `" or "`),
-				)
-				return nil, errors.New(errMsg)
+		if cq.multiselect {
+			if err := cq.checkSelectedCount(len(selection)); nil != err {
+				return nil, err
 			}
+			return selectedValues(selection), nil
+		}
+		return selection[0], nil
+	}
+}
+
+// selectedValues returns the values as []string when all of them are strings,
+// otherwise as []interface{}
+func selectedValues(values []interface{}) interface{} {
+	var strValues []string
+
+	for _, value := range values {
+		strValue, ok := value.(string)
+		if !ok {
+			return values
+		}
+		strValues = append(strValues, strValue)
+	}
+
+	return strValues
+}
+
+// findChoice returns the enabled choice selected by given answer.
+// A choice key matches first, labels are matched only when no key matches,
+// so the index keys of numeric choices like []int{1, 2, 3} do not collide with their labels.
+func (cq *ChoiceQuestion) findChoice(answer string) (Choice, error) {
+	results := cq.matchChoices(func(choice Choice) bool {
+		return choice.Key == answer
+	})
+	if 0 == len(results) {
+		results = cq.matchChoices(func(choice Choice) bool {
+			return choice.Label == answer
+		})
+	}
+
+	// an empty answer is a prefix of every choice, it selects nothing
+	if 0 == len(results) && cq.fuzzy && "" != answer {
+		lower := strings.ToLower(answer)
+		results = cq.matchChoices(func(choice Choice) bool {
+			return strings.HasPrefix(strings.ToLower(choice.Key), lower) ||
+				strings.HasPrefix(strings.ToLower(choice.Label), lower)
+		})
+	}
+
+	if len(results) > 1 {
+		var labels []string
+		for _, choice := range results {
+			labels = append(labels, choice.Label)
+		}
+		errMsg := fmt.Sprintf(
+			`The provided answer is ambigous. Value should be one of "%s"`,
+			strings.Join(labels, `" or "`),
+		)
+		return Choice{}, errors.New(errMsg)
+	}
 
-			if "" == result {
-				return nil, errors.New(fmt.Sprintf(cq.errorMessage, choiceValue))
+	if 0 == len(results) {
+		errMsg := fmt.Sprintf(cq.errorMessage, answer)
+		if cq.fuzzy && "" != answer {
+			if suggestions := cq.suggestChoices(answer); len(suggestions) > 0 {
+				errMsg = fmt.Sprintf(`%s. Did you mean "%s"?`, errMsg, strings.Join(suggestions, `" or "`))
 			}
+		}
+		return Choice{}, errors.New(errMsg)
+	}
+
+	return results[0], nil
+}
+
+// matchChoices returns the enabled choices accepted by given matcher
+func (cq *ChoiceQuestion) matchChoices(matcher func(choice Choice) bool) []Choice {
+	var results []Choice
 
-			multiSelectChoices = append(multiSelectChoices, result)
-			currentChoice = result
+	for _, choice := range cq.choices {
+		if !choice.Disabled && matcher(choice) {
+			results = append(results, choice)
 		}
+	}
 
-		if cq.multiselect {
-			return multiSelectChoices, nil
+	return results
+}
+
+// suggestChoices returns labels of the enabled choices closest to given answer,
+// ranked by their edit distance
+func (cq *ChoiceQuestion) suggestChoices(answer string) []string {
+	type suggestion struct {
+		label    string
+		distance int
+	}
+	var suggestions []suggestion
+	lower := strings.ToLower(answer)
+
+	for _, choice := range cq.matchChoices(func(choice Choice) bool { return true }) {
+		distance := levenshtein(lower, strings.ToLower(choice.Label))
+		if keyDistance := levenshtein(lower, strings.ToLower(choice.Key)); keyDistance < distance {
+			distance = keyDistance
+		}
+
+		// allow about one typo for every three characters
+		if distance <= len([]rune(answer))/3+1 {
+			suggestions = append(suggestions, suggestion{label: choice.Label, distance: distance})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	var labels []string
+	for i := 0; i < len(suggestions) && i < maxSuggestions; i++ {
+		labels = append(labels, suggestions[i].label)
+	}
+
+	return labels
+}
+
+// checkSelectedCount checks the number of selected choices against the minimum and maximum
+func (cq *ChoiceQuestion) checkSelectedCount(count int) error {
+	if cq.minSelected > 0 && count < cq.minSelected {
+		return fmt.Errorf("Select at least %d choice(s)", cq.minSelected)
+	}
+	if cq.maxSelected > 0 && count > cq.maxSelected {
+		return fmt.Errorf("Select at most %d choice(s)", cq.maxSelected)
+	}
+	return nil
+}
+
+// defaultLabel returns the labels of the choices selected by the given default value
+func (cq *ChoiceQuestion) defaultLabel(def string) string {
+	var labels []string
+	selection := []string{def}
+
+	if cq.multiselect {
+		selection = strings.Split(def, ",")
+	}
+
+	for _, selected := range selection {
+		selected = strings.TrimSpace(selected)
+		label := selected
+		for _, choice := range cq.choices {
+			if choice.Key == selected {
+				label = choice.Label
+				break
+			}
 		}
-		return currentChoice, nil
+		labels = append(labels, label)
 	}
+
+	return strings.Join(labels, ", ")
 }
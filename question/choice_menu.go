@@ -0,0 +1,280 @@
+package question
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/kilip/go-console/output"
+	"io"
+	"strings"
+)
+
+// choiceMenu renders the choices of a ChoiceQuestion as an interactive list
+// and lets the user move a highlighted cursor over them
+type choiceMenu struct {
+	reader   *bufio.Reader
+	output   output.IOutput
+	question *ChoiceQuestion
+	choices  []Choice
+	cursor   int
+	checked  []bool
+	message  string
+	drawn    int
+}
+
+// newChoiceMenu creates new choiceMenu object, with the default choices preselected
+func newChoiceMenu(reader *bufio.Reader, o output.IOutput, cq *ChoiceQuestion) *choiceMenu {
+	menu := &choiceMenu{
+		reader:   reader,
+		output:   o,
+		question: cq,
+		choices:  cq.GetChoices(),
+		cursor:   -1,
+	}
+	menu.checked = make([]bool, len(menu.choices))
+
+	if def := cq.GetDefault(); nil != def {
+		defaults := []string{fmt.Sprintf("%v", def)}
+		if cq.GetMultiSelect() {
+			defaults = strings.Split(defaults[0], ",")
+		}
+		for _, selected := range defaults {
+			if i := menu.indexOf(strings.TrimSpace(selected)); -1 != i {
+				menu.checked[i] = true
+				if -1 == menu.cursor {
+					menu.cursor = i
+				}
+			}
+		}
+	}
+
+	if -1 == menu.cursor {
+		menu.cursor = 0
+		if len(menu.choices) > 0 && menu.choices[0].Disabled {
+			menu.move(1)
+		}
+	}
+	if !menu.selectable() {
+		// there is no enabled choice to highlight
+		menu.cursor = -1
+	}
+
+	return menu
+}
+
+// indexOf returns the index of the enabled choice selected by given answer, or -1.
+// Like the validator of the question, it matches keys before labels.
+func (m *choiceMenu) indexOf(answer string) int {
+	for _, byKey := range []bool{true, false} {
+		for i, choice := range m.choices {
+			if choice.Disabled {
+				continue
+			}
+			if (byKey && choice.Key == answer) || (!byKey && choice.Label == answer) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// selectable returns whether the cursor is on an enabled choice
+func (m *choiceMenu) selectable() bool {
+	return m.cursor >= 0 && m.cursor < len(m.choices) && !m.choices[m.cursor].Disabled
+}
+
+// selectOne reads key presses until a choice is confirmed and returns its key,
+// which the validator resolves before any label so it selects that very choice
+func (m *choiceMenu) selectOne() (string, error) {
+	m.render()
+
+	for {
+		k, err := readKey(m.reader)
+		if errors.Is(err, io.EOF) {
+			err = ErrMissingInput
+		}
+		if nil != err {
+			return "", err
+		}
+
+		switch {
+		case keyEnter == k.code && m.selectable():
+			m.clear()
+			m.output.Write(m.question.GetPrompt())
+			m.output.Writeln(m.choices[m.cursor].Label)
+			return m.choices[m.cursor].Key, nil
+		case keyEOF == k.code:
+			return "", ErrMissingInput
+		case keyInterrupt == k.code:
+			return "", ErrInterrupted
+		case keyUp == k.code, keyRune == k.code && 'k' == k.char:
+			m.move(-1)
+			m.render()
+		case keyDown == k.code, keyRune == k.code && 'j' == k.char:
+			m.move(1)
+			m.render()
+		}
+	}
+}
+
+// selectMany reads key presses until the checked choices are confirmed
+// and returns their keys separated by comma
+func (m *choiceMenu) selectMany() (string, error) {
+	m.render()
+
+	for {
+		k, err := readKey(m.reader)
+		if errors.Is(err, io.EOF) {
+			err = ErrMissingInput
+		}
+		if nil != err {
+			return "", err
+		}
+
+		m.message = ""
+		switch {
+		case keyEnter == k.code:
+			keys, labels := m.selection()
+			if err := m.checkSelection(len(keys)); nil != err {
+				m.message = err.Error()
+				m.render()
+				continue
+			}
+			m.clear()
+			m.output.Write(m.question.GetPrompt())
+			m.output.Writeln(strings.Join(labels, ", "))
+			return strings.Join(keys, ","), nil
+		case keyEOF == k.code:
+			return "", ErrMissingInput
+		case keyInterrupt == k.code:
+			return "", ErrInterrupted
+		case keyUp == k.code, keyRune == k.code && 'k' == k.char:
+			m.move(-1)
+			m.render()
+		case keyDown == k.code, keyRune == k.code && 'j' == k.char:
+			m.move(1)
+			m.render()
+		case keyRune == k.code && ' ' == k.char && m.selectable():
+			m.toggle(m.cursor, !m.checked[m.cursor])
+			m.render()
+		case keyRune == k.code && 'a' == k.char:
+			for i := range m.checked {
+				m.toggle(i, true)
+			}
+			m.render()
+		case keyRune == k.code && 'i' == k.char:
+			for i := range m.checked {
+				m.toggle(i, !m.checked[i])
+			}
+			m.render()
+		}
+	}
+}
+
+// selection returns keys and labels of the checked choices
+func (m *choiceMenu) selection() (keys []string, labels []string) {
+	for i, choice := range m.choices {
+		if m.checked[i] {
+			keys = append(keys, choice.Key)
+			labels = append(labels, choice.Label)
+		}
+	}
+	return keys, labels
+}
+
+// checkSelection checks the number of checked choices,
+// at least one choice must be checked as an empty answer can not be validated
+func (m *choiceMenu) checkSelection(count int) error {
+	if 0 == count {
+		min := m.question.GetMinSelected()
+		if min < 1 {
+			min = 1
+		}
+		return fmt.Errorf("Select at least %d choice(s)", min)
+	}
+	return m.question.checkSelectedCount(count)
+}
+
+// toggle sets the checked state of the choice with given index, unless it is disabled
+func (m *choiceMenu) toggle(index int, checked bool) {
+	if !m.choices[index].Disabled {
+		m.checked[index] = checked
+	}
+}
+
+// move moves the cursor by given offset to the next enabled choice
+func (m *choiceMenu) move(offset int) {
+	count := len(m.choices)
+	if m.cursor < 0 {
+		return
+	}
+
+	for i := 0; i < count; i++ {
+		m.cursor = (m.cursor + offset + count) % count
+		if !m.choices[m.cursor].Disabled {
+			return
+		}
+	}
+}
+
+// render draws the list, replacing the previously drawn one
+func (m *choiceMenu) render() {
+	var lines []string
+
+	if m.question.GetMultiSelect() {
+		lines = append(lines, " <comment>Space</comment> to select, <comment>a</comment> to select all, <comment>i</comment> to invert, <comment>Enter</comment> to confirm")
+	}
+	for i, choice := range m.choices {
+		lines = append(lines, m.formatChoice(i, choice))
+	}
+	if "" != m.message {
+		lines = append(lines, fmt.Sprintf(" <error>%s</error>", m.message))
+	}
+
+	m.rewind()
+	for _, line := range lines {
+		m.write("\r\033[K")
+		m.output.Writeln(line)
+	}
+	// clear lines left from a previous, longer list
+	m.write("\033[J")
+	m.drawn = len(lines)
+}
+
+// formatChoice returns the line of the choice with given index
+func (m *choiceMenu) formatChoice(index int, choice Choice) string {
+	if m.question.GetMultiSelect() {
+		box := "[ ]"
+		if m.checked[index] {
+			box = "[x]"
+		}
+		if index == m.cursor {
+			return fmt.Sprintf(" <info>> %s %s</info>", box, choice.describe())
+		}
+		return fmt.Sprintf("   %s %s", box, choice.describe())
+	}
+
+	if index == m.cursor {
+		return fmt.Sprintf(" <info>> [%s] %s</info>", choice.Key, choice.describe())
+	}
+	return fmt.Sprintf("   [<comment>%s</comment>] %s", choice.Key, choice.describe())
+}
+
+// clear removes the drawn list from the terminal
+func (m *choiceMenu) clear() {
+	m.rewind()
+	m.write("\r\033[J")
+	m.drawn = 0
+}
+
+// rewind moves the terminal cursor to the first line of the drawn list
+func (m *choiceMenu) rewind() {
+	if m.drawn > 0 {
+		m.write(fmt.Sprintf("\033[%dA", m.drawn))
+	}
+}
+
+// write writes given text into the output without formatting
+func (m *choiceMenu) write(text string) {
+	m.output.WriteO(text, false, output.FormatRaw)
+}
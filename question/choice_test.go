@@ -192,27 +192,291 @@ func TestChoiceQuestion_SelectAssociativeChoices(t *testing.T) {
 
 func TestChoiceQuestion_SelectWithNonStringChoices(t *testing.T) {
 	c := qt.New(t)
-	q := NewChoiceQuestion("A question", []interface{}{
-		&StringChoice{string: "foo"},
-		&StringChoice{string: "bar"},
-		&StringChoice{string: "baz"},
-	})
+	foo, bar, baz := &StringChoice{string: "foo"}, &StringChoice{string: "bar"}, &StringChoice{string: "baz"}
+	q := NewChoiceQuestion("A question", []interface{}{foo, bar, baz})
 	validator := q.GetValidator()
 
 	// begin testing
 
-	// answer can be selected by its string value
+	// answer can be selected by its string value, the choice itself is returned
 	out, err := validator("foo")
 	c.Assert(err, qt.IsNil)
-	c.Assert(out, qt.Equals, "foo")
+	c.Assert(out, qt.Equals, foo)
 
 	// answer can be selected by index
 	out, err = validator("0")
 	c.Assert(err, qt.IsNil)
-	c.Assert(out, qt.Equals, "foo")
+	c.Assert(out, qt.Equals, foo)
 
 	// test multi select
 	q.SetMultiSelect(true)
 	out, err = validator("baz, bar")
-	c.Assert(strings.Join(out.([]string), ","), qt.Equals, "baz,bar")
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.HasLen, 2)
+	c.Assert(out.([]interface{})[0], qt.Equals, baz)
+	c.Assert(out.([]interface{})[1], qt.Equals, bar)
+}
+
+func TestChoiceQuestion_SetChoices(t *testing.T) {
+	type cs struct {
+		Name     string
+		Choices  interface{}
+		Keys     []string
+		Labels   []string
+		Expected interface{}
+	}
+	cases := []cs{
+		{Name: "int slice", Choices: []int{8080, 443}, Keys: []string{"0", "1"}, Labels: []string{"8080", "443"}, Expected: 443},
+		{Name: "array", Choices: [2]string{"dev", "prod"}, Keys: []string{"0", "1"}, Labels: []string{"dev", "prod"}, Expected: "prod"},
+		{Name: "mixed slice", Choices: []interface{}{"dev", 2}, Keys: []string{"0", "1"}, Labels: []string{"dev", "2"}, Expected: 2},
+		{
+			Name:     "string map",
+			Choices:  map[string]string{"p": "Production", "d": "Development"},
+			Keys:     []string{"d", "p"},
+			Labels:   []string{"Development", "Production"},
+			Expected: "p",
+		},
+		{
+			Name:     "int keys sorted numerically",
+			Choices:  map[int]string{10: "Ten", 2: "Two"},
+			Keys:     []string{"2", "10"},
+			Labels:   []string{"Two", "Ten"},
+			Expected: 10,
+		},
+		{Name: "nil", Choices: nil},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			q := NewChoiceQuestion("A question", testCase.Choices)
+
+			var keys, labels []string
+			for _, choice := range q.GetChoices() {
+				keys = append(keys, choice.Key)
+				labels = append(labels, choice.Label)
+			}
+			c.Assert(keys, qt.DeepEquals, testCase.Keys)
+			c.Assert(labels, qt.DeepEquals, testCase.Labels)
+
+			if 0 != len(testCase.Keys) {
+				out, err := q.GetValidator()(testCase.Labels[1])
+				c.Assert(err, qt.IsNil)
+				c.Assert(out, qt.Equals, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestChoiceQuestion_SetChoicesErrors(t *testing.T) {
+	c := qt.New(t)
+	q := NewChoiceQuestion("A question", []string{"dev"})
+
+	c.Assert(q.SetChoices("dev"), qt.ErrorMatches, `Choices of type string are not supported`)
+	c.Assert(q.SetChoices(42), qt.ErrorMatches, `Choices of type int are not supported`)
+	c.Assert(q.GetChoices(), qt.HasLen, 1)
+	c.Assert(func() { NewChoiceQuestion("A question", 42) }, qt.PanicMatches, `Choices of type int are not supported`)
+}
+
+func TestChoiceQuestion_SelectedCount(t *testing.T) {
+	c := qt.New(t)
+	q := NewChoiceQuestion("A question", []string{"foo", "bar", "baz"})
+	q.SetMultiSelect(true)
+	q.SetMinSelected(2)
+	q.SetMaxSelected(2)
+	validator := q.GetValidator()
+
+	c.Assert(q.GetMinSelected(), qt.Equals, 2)
+	c.Assert(q.GetMaxSelected(), qt.Equals, 2)
+
+	_, err := validator("foo")
+	c.Assert(err, qt.ErrorMatches, `Select at least 2 choice\(s\)`)
+
+	_, err = validator("foo,bar,baz")
+	c.Assert(err, qt.ErrorMatches, `Select at most 2 choice\(s\)`)
+
+	out, err := validator("foo,baz")
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.DeepEquals, []string{"foo", "baz"})
+}
+
+func TestChoiceQuestion_TypedChoices(t *testing.T) {
+	c := qt.New(t)
+	q := NewChoiceQuestion("Replicas?", []Choice{
+		{Key: "s", Label: "Small", Value: 1, Description: "a single replica"},
+		{Key: "m", Label: "Medium", Value: 3},
+		{Key: "l", Label: "Large", Value: 5, Disabled: true},
+	})
+	validator := q.GetValidator()
+
+	c.Assert(q.GetChoices()[0].Key, qt.Equals, "s")
+
+	out, err := validator("m")
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.Equals, 3)
+
+	out, err = validator("Small")
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.Equals, 1)
+
+	_, err = validator("l")
+	c.Assert(err, qt.ErrorMatches, `Value "l" is invalid`)
+
+	q.SetMultiSelect(true)
+	out, err = validator("s,m")
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.DeepEquals, []interface{}{1, 3})
+}
+
+func TestChoiceQuestion_OrderedChoices(t *testing.T) {
+	c := qt.New(t)
+	q := NewChoiceQuestion("A question", map[string]interface{}{
+		"c": "Third",
+		"a": "First",
+		"b": "Second",
+	})
+
+	var keys []string
+	for _, choice := range q.GetChoices() {
+		keys = append(keys, choice.Key)
+	}
+	c.Assert(keys, qt.DeepEquals, []string{"a", "b", "c"})
+}
+
+func TestChoiceQuestion_Ambiguous(t *testing.T) {
+	c := qt.New(t)
+	q := NewChoiceQuestion("A question", []Choice{
+		{Key: "a", Label: "Same", Value: "first"},
+		{Key: "b", Label: "Same", Value: "second"},
+	})
+
+	for i := 0; i < 10; i++ {
+		_, err := q.GetValidator()("Same")
+		c.Assert(err, qt.ErrorMatches, `The provided answer is ambigous. Value should be one of "Same" or "Same"`)
+	}
+}
+
+func TestChoiceQuestion_KeyMatchesBeforeLabel(t *testing.T) {
+	type cs struct {
+		Name     string
+		Choices  interface{}
+		Multi    bool
+		Answer   string
+		Expected interface{}
+	}
+	cases := []cs{
+		{Name: "index key of int choices", Choices: []int{1, 2, 3}, Answer: "2", Expected: 3},
+		{Name: "label of int choices without matching key", Choices: []int{1, 2, 3}, Answer: "3", Expected: 3},
+		{Name: "index key of string choices", Choices: []string{"1", "foo"}, Answer: "1", Expected: "foo"},
+		{Name: "label of string choices", Choices: []string{"1", "foo"}, Answer: "foo", Expected: "foo"},
+		{Name: "keys of int choices in multi select", Choices: []int{1, 2, 3}, Multi: true, Answer: "0,2", Expected: []interface{}{1, 3}},
+		{
+			Name:     "label when the key is disabled",
+			Choices:  []Choice{{Key: "1", Label: "One", Value: 1, Disabled: true}, {Key: "2", Label: "1", Value: 2}},
+			Answer:   "1",
+			Expected: 2,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			q := NewChoiceQuestion("A question", testCase.Choices)
+			q.SetMultiSelect(testCase.Multi)
+
+			out, err := q.GetValidator()(testCase.Answer)
+			c.Assert(err, qt.IsNil)
+			c.Assert(out, qt.DeepEquals, testCase.Expected)
+		})
+	}
+}
+
+func TestChoiceQuestion_FuzzyMatching(t *testing.T) {
+	type cs struct {
+		Name     string
+		Answer   string
+		Expected interface{}
+		Error    string
+	}
+	cases := []cs{
+		{Name: "exact match", Answer: "staging", Expected: "staging"},
+		{Name: "case-insensitive prefix", Answer: "PROD", Expected: "production"},
+		{Name: "prefix of key", Answer: "de", Expected: "development"},
+		{
+			Name:   "ambiguous prefix",
+			Answer: "st",
+			Error:  `The provided answer is ambigous. Value should be one of "staging" or "stable"`,
+		},
+		{
+			Name:   "suggest closest choice",
+			Answer: "stagign",
+			Error:  `Value "stagign" is invalid. Did you mean "staging"\?`,
+		},
+		{
+			Name:   "suggest closest choices ranked by distance",
+			Answer: "stabing",
+			Error:  `Value "stabing" is invalid. Did you mean "staging" or "stable"\?`,
+		},
+		{
+			Name:   "no suggestion for unrelated answer",
+			Answer: "foo",
+			Error:  `Value "foo" is invalid`,
+		},
+		{
+			Name:   "empty answer",
+			Answer: "",
+			Error:  `Value "" is invalid`,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			q := NewChoiceQuestion("Environment?", []Choice{
+				{Key: "dev", Label: "development", Value: "development"},
+				{Key: "stg", Label: "staging", Value: "staging"},
+				{Key: "stb", Label: "stable", Value: "stable"},
+				{Key: "prod", Label: "production", Value: "production"},
+			})
+			q.SetFuzzyMatching(true)
+			c.Assert(q.IsFuzzyMatching(), qt.IsTrue)
+
+			out, err := q.GetValidator()(testCase.Answer)
+			if "" != testCase.Error {
+				c.Assert(err, qt.ErrorMatches, testCase.Error)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(out, qt.Equals, testCase.Expected)
+		})
+	}
+}
+
+func TestChoiceQuestion_FuzzyMatchingEmptyAnswerWithSingleChoice(t *testing.T) {
+	c := qt.New(t)
+	q := NewChoiceQuestion("Environment?", []string{"staging"})
+	q.SetFuzzyMatching(true)
+
+	_, err := q.GetValidator()("")
+	c.Assert(err, qt.ErrorMatches, `Value "" is invalid`)
+}
+
+func TestChoiceQuestion_FuzzyMatchingDisabled(t *testing.T) {
+	c := qt.New(t)
+	q := NewChoiceQuestion("Environment?", []string{"staging", "production"})
+
+	c.Assert(q.IsFuzzyMatching(), qt.IsFalse)
+	_, err := q.GetValidator()("prod")
+	c.Assert(err, qt.ErrorMatches, `Value "prod" is invalid`)
+	_, err = q.GetValidator()("stagign")
+	c.Assert(err, qt.ErrorMatches, `Value "stagign" is invalid`)
+}
+
+func TestLevenshtein(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(levenshtein("", "abc"), qt.Equals, 3)
+	c.Assert(levenshtein("kitten", "sitting"), qt.Equals, 3)
+	c.Assert(levenshtein("staging", "staging"), qt.Equals, 0)
+	c.Assert(levenshtein("über", "uber"), qt.Equals, 1)
 }
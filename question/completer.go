@@ -0,0 +1,110 @@
+package question
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// PathCompleter returns an autocompleter callback suggesting the entries of the directory
+// being typed. The leading "~" is expanded to the user home directory, directories are
+// suggested with a trailing "/" and hidden entries only when the typed name starts with a dot.
+// Files are filtered by the given glob patterns like "*.yaml", an extension like ".yaml"
+// is accepted as a shortcut for "*.yaml". Without patterns every file is suggested.
+func PathCompleter(patterns ...string) func(input string) []string {
+	globs := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, ".") && !strings.ContainsAny(pattern, `*?[\`) {
+			pattern = "*" + pattern
+		}
+		globs = append(globs, pattern)
+	}
+
+	return func(input string) []string {
+		if "~" == input {
+			return []string{"~/"}
+		}
+
+		separators := "/"
+		if "windows" == runtime.GOOS {
+			separators = `/\`
+		}
+		index := strings.LastIndexAny(input, separators)
+		typedDir, typedName := input[:index+1], input[index+1:]
+
+		dir, ok := completionDir(typedDir)
+		if !ok {
+			return nil
+		}
+		entries, err := os.ReadDir(dir)
+		if nil != err {
+			return nil
+		}
+
+		var suggestions []string
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, typedName) {
+				continue
+			}
+			if strings.HasPrefix(name, ".") && !strings.HasPrefix(typedName, ".") {
+				continue
+			}
+
+			if isDirEntry(dir, entry) {
+				suggestions = append(suggestions, typedDir+name+"/")
+			} else if matchesAny(globs, name) {
+				suggestions = append(suggestions, typedDir+name)
+			}
+		}
+
+		return suggestions
+	}
+}
+
+// completionDir returns the directory to list for the typed directory,
+// expanding the leading "~" to the user home directory
+func completionDir(typedDir string) (string, bool) {
+	if "" == typedDir {
+		return ".", true
+	}
+
+	if strings.HasPrefix(typedDir, "~/") || "windows" == runtime.GOOS && strings.HasPrefix(typedDir, `~\`) {
+		home, err := os.UserHomeDir()
+		if nil != err {
+			return "", false
+		}
+		return filepath.Join(home, typedDir[2:]), true
+	}
+
+	return typedDir, true
+}
+
+// isDirEntry returns whether the entry is a directory or a symbolic link to a directory
+func isDirEntry(dir string, entry os.DirEntry) bool {
+	if entry.IsDir() {
+		return true
+	}
+	if 0 == entry.Type()&os.ModeSymlink {
+		return false
+	}
+
+	info, err := os.Stat(filepath.Join(dir, entry.Name()))
+	return nil == err && info.IsDir()
+}
+
+// matchesAny returns whether the name matches one of the glob patterns,
+// any name matches when there is no pattern
+func matchesAny(globs []string, name string) bool {
+	if 0 == len(globs) {
+		return true
+	}
+
+	for _, glob := range globs {
+		if matched, _ := filepath.Match(glob, name); matched {
+			return true
+		}
+	}
+	return false
+}
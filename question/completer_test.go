@@ -0,0 +1,107 @@
+package question
+
+import (
+	qt "github.com/frankban/quicktest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// createTree creates files and directories, names ending with "/" are directories
+func createTree(t *testing.T, names ...string) string {
+	t.Helper()
+	root := t.TempDir()
+
+	for _, name := range names {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if '/' == name[len(name)-1] {
+			if err := os.MkdirAll(path, 0755); nil != err {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); nil != err {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); nil != err {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestPathCompleter(t *testing.T) {
+	root := createTree(t,
+		"config.yaml", "config.json", "compose.yml", ".env",
+		"configs/", "configs/app.yaml", ".git/", "README.md",
+	)
+	wd, err := os.Getwd()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(root); nil != err {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	type cs struct {
+		Name     string
+		Patterns []string
+		Input    string
+		Expected []string
+	}
+	cases := []cs{
+		{Name: "current directory", Input: "", Expected: []string{"README.md", "compose.yml", "config.json", "config.yaml", "configs/"}},
+		{Name: "prefix", Input: "conf", Expected: []string{"config.json", "config.yaml", "configs/"}},
+		{Name: "hidden entries", Input: ".", Expected: []string{".env", ".git/"}},
+		{Name: "sub directory", Input: "configs/", Expected: []string{"configs/app.yaml"}},
+		{Name: "relative directory", Input: "./conf", Expected: []string{"./config.json", "./config.yaml", "./configs/"}},
+		{Name: "glob pattern", Patterns: []string{"*.yaml", "*.yml"}, Input: "", Expected: []string{"compose.yml", "config.yaml", "configs/"}},
+		{Name: "extension", Patterns: []string{".json"}, Input: "c", Expected: []string{"config.json", "configs/"}},
+		{Name: "no match", Input: "foo", Expected: nil},
+		{Name: "missing directory", Input: "foo/bar", Expected: nil},
+		{Name: "absolute path", Input: root + "/configs/a", Expected: []string{root + "/configs/app.yaml"}},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			completer := PathCompleter(testCase.Patterns...)
+			c.Assert(completer(testCase.Input), qt.DeepEquals, testCase.Expected)
+		})
+	}
+}
+
+func TestPathCompleter_ExpandsHome(t *testing.T) {
+	c := qt.New(t)
+	home := createTree(t, "projects/", "profile.txt", ".bashrc")
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	completer := PathCompleter()
+
+	c.Assert(completer("~"), qt.DeepEquals, []string{"~/"})
+	c.Assert(completer("~/pro"), qt.DeepEquals, []string{"~/profile.txt", "~/projects/"})
+	c.Assert(completer("~/."), qt.DeepEquals, []string{"~/.bashrc"})
+}
+
+func TestPathCompleter_FollowsSymlinks(t *testing.T) {
+	c := qt.New(t)
+	root := createTree(t, "target/")
+	if err := os.Symlink(filepath.Join(root, "target"), filepath.Join(root, "link")); nil != err {
+		c.Skip("symbolic links are not available")
+	}
+
+	c.Assert(PathCompleter("*.yaml")(root+"/l"), qt.DeepEquals, []string{root + "/link/"})
+}
+
+func TestNewPathQuestion_CompletesPaths(t *testing.T) {
+	c := qt.New(t)
+	root := createTree(t, "config.yaml")
+	q := NewPathQuestion("Configuration file?")
+
+	c.Assert(q.GetAutoCompleterCallback(), qt.IsNotNil)
+	c.Assert(q.GetAutoCompleterCallback()(root+"/c"), qt.DeepEquals, []string{root + "/config.yaml"})
+}
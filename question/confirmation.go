@@ -1,44 +1,163 @@
 package question
 
 import (
+	"fmt"
 	"regexp"
-	"strconv"
+	"strings"
+)
+
+// ConfirmationLocale holds the answers of a yes/no question in a language
+type ConfirmationLocale struct {
+	// Yes and No are the answers shown in the prompt
+	Yes string
+	No  string
+	// TrueAnswerRegex and FalseAnswerRegex are matched against the trimmed answer, ignoring case
+	TrueAnswerRegex  string
+	FalseAnswerRegex string
+}
+
+// Locales of the yes/no answers shipped with the library
+var (
+	LocaleEnglish    = ConfirmationLocale{Yes: "yes", No: "no", TrueAnswerRegex: `^y(es)?$`, FalseAnswerRegex: `^no?$`}
+	LocaleGerman     = ConfirmationLocale{Yes: "ja", No: "nein", TrueAnswerRegex: `^ja?$`, FalseAnswerRegex: `^n(ein)?$`}
+	LocaleIndonesian = ConfirmationLocale{Yes: "ya", No: "tidak", TrueAnswerRegex: `^(y|ya|iya)$`, FalseAnswerRegex: `^(t|tidak|tdk|gak|nggak)$`}
 )
 
 // ConfirmationQuestion Represents a yes/no question.
-// * true: when answer with yes or 1
-// * false: when answer is no or 0
-// Answering this question with other value like foo, bar will result false
+// * true: when answered with a yes of one of the locales, true or 1
+// * false: when answered with a no of one of the locales, false or 0
+// Other answers like foo or bar are rejected and the question is asked again.
 type ConfirmationQuestion struct {
-	trueAnswerRegex string
+	locales      []ConfirmationLocale
+	matchers     []localeMatcher
+	errorMessage string
 	*Question
 }
 
-// NewConfirmationQuestion creates new ConfirmationQuestion object
+// localeMatcher holds the compiled answer regexes of a locale, nil when the locale has no regex
+type localeMatcher struct {
+	trueRegex  *regexp.Regexp
+	falseRegex *regexp.Regexp
+}
+
+// NewConfirmationQuestion creates new ConfirmationQuestion object, answered in english
 func NewConfirmationQuestion(question string, defaultValue bool) *ConfirmationQuestion {
 	q := &ConfirmationQuestion{
-		trueAnswerRegex: "^y|^Y",
-		Question:        NewQuestion(question),
+		errorMessage: `The answer "%s" is not recognised, please answer %s or %s`,
+		Question:     NewQuestion(question),
 	}
+	_ = q.SetLocales(LocaleEnglish)
 	q.SetDefault(defaultValue)
 	q.SetNormalizer(q.getDefaultNormalizer())
+	q.SetValidator(q.getDefaultValidator())
 
 	return q
 }
 
-// getDefaultNormalizer will sets default normalization for ConfirmationQuestion
+// SetLocales sets the locales of the accepted answers,
+// the first locale gives the answers shown in the prompt.
+// The locales are not changed when one of their regexes is invalid.
+func (cq *ConfirmationQuestion) SetLocales(locales ...ConfirmationLocale) error {
+	matchers := make([]localeMatcher, 0, len(locales))
+
+	for _, locale := range locales {
+		var matcher localeMatcher
+		var err error
+		if matcher.trueRegex, err = compileAnswerRegex(locale.TrueAnswerRegex); nil != err {
+			return err
+		}
+		if matcher.falseRegex, err = compileAnswerRegex(locale.FalseAnswerRegex); nil != err {
+			return err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	cq.locales = locales
+	cq.matchers = matchers
+	return nil
+}
+
+// GetLocales returns the locales of the accepted answers
+func (cq *ConfirmationQuestion) GetLocales() []ConfirmationLocale {
+	return cq.locales
+}
+
+// SetErrorMessage sets the error message of unrecognised answers,
+// formatted with the answer and the yes and no answers of the first locale
+func (cq *ConfirmationQuestion) SetErrorMessage(message string) {
+	cq.errorMessage = message
+}
+
+// GetErrorMessage returns the error message of unrecognised answers
+func (cq *ConfirmationQuestion) GetErrorMessage() string {
+	return cq.errorMessage
+}
+
+// labels returns the yes and no answers shown in the prompt
+func (cq *ConfirmationQuestion) labels() (string, string) {
+	if 0 == len(cq.locales) {
+		return LocaleEnglish.Yes, LocaleEnglish.No
+	}
+	return cq.locales[0].Yes, cq.locales[0].No
+}
+
+// getDefaultNormalizer will sets default normalization for ConfirmationQuestion,
+// unrecognised answers are returned unchanged to be rejected by the validator
 func (cq *ConfirmationQuestion) getDefaultNormalizer() func(input string) interface{} {
 	return func(answer string) interface{} {
-		defaultValue := cq.defaultValue
-		if val, err := strconv.ParseBool(answer); err == nil {
+		answer = strings.TrimSpace(answer)
+		if def := cq.GetDefault(); "" == answer && nil != def {
+			return def
+		}
+
+		for _, matcher := range cq.matchers {
+			if nil != matcher.trueRegex && matcher.trueRegex.MatchString(answer) {
+				return true
+			}
+			if nil != matcher.falseRegex && matcher.falseRegex.MatchString(answer) {
+				return false
+			}
+		}
+		if val, ok := parseStrictBool(answer); ok {
 			return val
 		}
-		regex := regexp.MustCompile(cq.trueAnswerRegex)
-		answerIsTrue := regex.MatchString(answer)
-		if false == defaultValue {
-			return "" != answer && answerIsTrue
+
+		return answer
+	}
+}
+
+// getDefaultValidator rejects answers not recognised by the normalizer
+func (cq *ConfirmationQuestion) getDefaultValidator() func(input string) (interface{}, error) {
+	return func(answer string) (interface{}, error) {
+		if val, ok := parseStrictBool(answer); ok {
+			return val, nil
 		}
 
-		return "" == answer || answerIsTrue
+		yes, no := cq.labels()
+		return nil, fmt.Errorf(cq.errorMessage, answer, yes, no)
+	}
+}
+
+// parseStrictBool parses the answers accepted in every locale: true, false, 1 and 0
+func parseStrictBool(answer string) (bool, bool) {
+	switch answer {
+	case "true", "1":
+		return true, true
+	case "false", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// compileAnswerRegex compiles the answer regex of a locale, matched ignoring case
+func compileAnswerRegex(pattern string) (*regexp.Regexp, error) {
+	if "" == pattern {
+		return nil, nil
+	}
+
+	regex, err := regexp.Compile("(?i)" + pattern)
+	if nil != err {
+		return nil, fmt.Errorf(`The answer regex "%s" is invalid: %s`, pattern, err)
 	}
+	return regex, nil
 }
@@ -22,7 +22,7 @@ func TestConfirmationQuestion_RegexUseCases(t *testing.T) {
 		},
 		{
 			DefaultValue: true,
-			Answers:      []string{"n", "N", "no", "NO", "nO", "foo", "0"},
+			Answers:      []string{"n", "N", "no", "NO", "nO", "0"},
 			Expected:     false,
 			Name:         "When default is true, the normalizer must return false for %s",
 		},
@@ -34,7 +34,7 @@ func TestConfirmationQuestion_RegexUseCases(t *testing.T) {
 		},
 		{
 			DefaultValue: false,
-			Answers:      []string{"n", "N", "no", "NO", "nO", "foo", "0", ""},
+			Answers:      []string{"n", "N", "no", "NO", "nO", "0", ""},
 			Expected:     false,
 			Name:         "When default is false, the normalizer must return false for %s",
 		},
@@ -52,3 +52,77 @@ func TestConfirmationQuestion_RegexUseCases(t *testing.T) {
 		})
 	}
 }
+
+func TestConfirmationQuestion_UnrecognisedAnswers(t *testing.T) {
+	c := qt.New(t)
+	q := NewConfirmationQuestion("A question", true)
+	normalizer := q.GetNormalizer()
+	validator := q.GetValidator()
+
+	for _, answer := range []string{"foo", "yeah", "nein", "ya", "t", "TRUE"} {
+		c.Assert(normalizer(answer), qt.Equals, answer)
+		_, err := validator(answer)
+		c.Assert(err, qt.ErrorMatches, `The answer "`+answer+`" is not recognised, please answer yes or no`)
+	}
+
+	value, err := validator("true")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.IsTrue)
+}
+
+func TestConfirmationQuestion_Locales(t *testing.T) {
+	type cs struct {
+		Name     string
+		Locales  []ConfirmationLocale
+		True     []string
+		False    []string
+		Rejected []string
+	}
+	cases := []cs{
+		{Name: "english", Locales: []ConfirmationLocale{LocaleEnglish}, True: []string{"y", "Yes", "1"}, False: []string{"n", "NO", "false"}, Rejected: []string{"ja", "tidak"}},
+		{Name: "german", Locales: []ConfirmationLocale{LocaleGerman}, True: []string{"j", "Ja", "true"}, False: []string{"n", "Nein", "0"}, Rejected: []string{"yes", "no", "t", "f", "T", "TRUE", "F"}},
+		{Name: "indonesian", Locales: []ConfirmationLocale{LocaleIndonesian}, True: []string{"y", "Ya", "iya"}, False: []string{"t", "Tidak", "tdk"}, Rejected: []string{"no", "nein"}},
+		{Name: "german and english", Locales: []ConfirmationLocale{LocaleGerman, LocaleEnglish}, True: []string{"ja", "yes"}, False: []string{"nein", "no"}, Rejected: []string{"ya"}},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			q := NewConfirmationQuestion("A question", false)
+			c.Assert(q.SetLocales(testCase.Locales...), qt.IsNil)
+			c.Assert(q.GetLocales(), qt.DeepEquals, testCase.Locales)
+			normalizer := q.GetNormalizer()
+
+			for _, answer := range testCase.True {
+				c.Assert(normalizer(answer), qt.Equals, true, qt.Commentf(answer))
+			}
+			for _, answer := range testCase.False {
+				c.Assert(normalizer(answer), qt.Equals, false, qt.Commentf(answer))
+			}
+			for _, answer := range testCase.Rejected {
+				c.Assert(normalizer(answer), qt.Equals, answer)
+			}
+		})
+	}
+}
+
+func TestConfirmationQuestion_ErrorMessage(t *testing.T) {
+	c := qt.New(t)
+	q := NewConfirmationQuestion("Fortfahren?", true)
+	_ = q.SetLocales(LocaleGerman)
+	q.SetErrorMessage(`Die Antwort "%s" ist ungültig, bitte mit %s oder %s antworten`)
+
+	c.Assert(q.GetErrorMessage(), qt.Equals, `Die Antwort "%s" ist ungültig, bitte mit %s oder %s antworten`)
+	_, err := q.GetValidator()("vielleicht")
+	c.Assert(err, qt.ErrorMatches, `Die Antwort "vielleicht" ist ungültig, bitte mit ja oder nein antworten`)
+}
+
+func TestConfirmationQuestion_InvalidLocale(t *testing.T) {
+	c := qt.New(t)
+	q := NewConfirmationQuestion("A question", false)
+
+	err := q.SetLocales(LocaleGerman, ConfirmationLocale{Yes: "oui", No: "non", TrueAnswerRegex: `^(oui`, FalseAnswerRegex: `^non$`})
+	c.Assert(err, qt.ErrorMatches, `The answer regex "\^\(oui" is invalid: .*`)
+	c.Assert(q.GetLocales(), qt.DeepEquals, []ConfirmationLocale{LocaleEnglish})
+	c.Assert(q.GetNormalizer()("yes"), qt.Equals, true)
+}
@@ -0,0 +1,173 @@
+package question
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// errAutoAccept is returned by contextReader when the auto-accept delay is exceeded
+var errAutoAccept = errors.New("The auto-accept delay is exceeded.")
+
+// readResult holds the outcome of a read started in background
+type readResult struct {
+	data []byte
+	err  error
+}
+
+// pollInterval is the delay after which an abandoned wait for input stops
+const pollInterval = 50 * time.Millisecond
+
+// contextReader reads from input until its context is done.
+// When input has a file descriptor which can be polled, the reader waits in background
+// until data is available and reads it only when asked, so an interrupted read consumes nothing.
+// Otherwise a read interrupted by the context keeps running in background,
+// its data is returned by the next read so typed answers are not lost.
+type contextReader struct {
+	input    io.Reader
+	fd       uintptr
+	pollable bool
+	ctx      context.Context
+	deadline time.Time
+	tick     func(remaining time.Duration)
+	interval time.Duration
+	pending  chan readResult
+	stop     chan struct{}
+	rest     []byte
+	err      error
+}
+
+// newContextReader creates new contextReader object reading from input
+func newContextReader(input io.Reader) *contextReader {
+	cr := &contextReader{
+		input:    input,
+		ctx:      context.Background(),
+		interval: time.Second,
+	}
+
+	if file, ok := input.(interface{ Fd() uintptr }); ok {
+		cr.fd = file.Fd()
+		_, err := waitReadable(cr.fd, 0)
+		cr.pollable = nil == err
+	}
+
+	return cr
+}
+
+// reset stops watching the context and the auto-accept deadline
+func (cr *contextReader) reset() {
+	cr.ctx = context.Background()
+	cr.deadline = time.Time{}
+	cr.tick = nil
+}
+
+// Read reads from input, returning the context error when the context is done
+// and errAutoAccept when the deadline is exceeded before any input is received.
+// The tick callback is called every interval, one second by default, while waiting for the deadline.
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if len(cr.rest) > 0 || nil != cr.err {
+		return cr.drain(p)
+	}
+
+	if err := cr.ctx.Err(); nil != err {
+		return 0, err
+	}
+
+	if nil == cr.pending {
+		if nil == cr.ctx.Done() && cr.deadline.IsZero() {
+			return cr.input.Read(p)
+		}
+
+		cr.start(len(p))
+	}
+
+	var timeout, ticks <-chan time.Time
+	if !cr.deadline.IsZero() {
+		timer := time.NewTimer(time.Until(cr.deadline))
+		defer timer.Stop()
+		timeout = timer.C
+
+		if nil != cr.tick {
+			ticker := time.NewTicker(cr.interval)
+			defer ticker.Stop()
+			ticks = ticker.C
+		}
+	}
+
+	for {
+		select {
+		case result := <-cr.pending:
+			cr.pending = nil
+			// the user is answering, the default answer is no longer accepted automatically
+			cr.deadline = time.Time{}
+			if cr.pollable && nil == result.err {
+				return cr.input.Read(p)
+			}
+			cr.rest, cr.err = result.data, result.err
+			return cr.drain(p)
+		case <-cr.ctx.Done():
+			cr.abandon()
+			return 0, cr.ctx.Err()
+		case <-timeout:
+			cr.abandon()
+			return 0, errAutoAccept
+		case <-ticks:
+			cr.tick(time.Until(cr.deadline))
+		}
+	}
+}
+
+// start starts waiting for input in background. A pollable input is only waited for,
+// other inputs are read into a buffer of given size.
+func (cr *contextReader) start(size int) {
+	cr.pending = make(chan readResult, 1)
+
+	if !cr.pollable {
+		go func(input io.Reader, pending chan<- readResult) {
+			buf := make([]byte, size)
+			n, err := input.Read(buf)
+			pending <- readResult{data: buf[:n], err: err}
+		}(cr.input, cr.pending)
+		return
+	}
+
+	cr.stop = make(chan struct{})
+	go func(fd uintptr, pending chan<- readResult, stop <-chan struct{}) {
+		for {
+			ready, err := waitReadable(fd, pollInterval)
+			if ready || nil != err {
+				pending <- readResult{err: err}
+				return
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}(cr.fd, cr.pending, cr.stop)
+}
+
+// abandon stops waiting for a pollable input, so the input typed after the question
+// is left to the next reader. A read of other inputs keeps running in background.
+func (cr *contextReader) abandon() {
+	if !cr.pollable || nil == cr.pending {
+		return
+	}
+	close(cr.stop)
+	cr.pending = nil
+}
+
+// drain returns the data and error of a completed background read
+func (cr *contextReader) drain(p []byte) (int, error) {
+	n := copy(p, cr.rest)
+	cr.rest = cr.rest[n:]
+	if len(cr.rest) > 0 {
+		return n, nil
+	}
+
+	err := cr.err
+	cr.err = nil
+	return n, err
+}
@@ -0,0 +1,167 @@
+package question
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// EditorQuestion represents a question answered in a text editor.
+// The editor is given by the VISUAL or EDITOR environment variable, falling back to vi.
+type EditorQuestion struct {
+	instructions  string
+	commentPrefix string
+	extension     string
+	draft         string
+	*Question
+}
+
+// NewEditorQuestion creates new EditorQuestion object
+func NewEditorQuestion(question string) *EditorQuestion {
+	return &EditorQuestion{
+		commentPrefix: "#",
+		extension:     ".txt",
+		Question:      NewQuestion(question),
+	}
+}
+
+// SetInstructions sets the instructions written as comments into the edited file
+func (eq *EditorQuestion) SetInstructions(instructions string) {
+	eq.instructions = instructions
+}
+
+// GetInstructions returns the instructions written as comments into the edited file
+func (eq *EditorQuestion) GetInstructions() string {
+	return eq.instructions
+}
+
+// SetCommentPrefix sets the prefix of the comment lines removed from the answer
+func (eq *EditorQuestion) SetCommentPrefix(prefix string) {
+	eq.commentPrefix = prefix
+}
+
+// GetCommentPrefix returns the prefix of the comment lines removed from the answer
+func (eq *EditorQuestion) GetCommentPrefix() string {
+	return eq.commentPrefix
+}
+
+// SetExtension sets the extension of the edited file, e.g. ".yaml" to enable syntax highlighting
+func (eq *EditorQuestion) SetExtension(extension string) {
+	eq.extension = extension
+}
+
+// GetExtension returns the extension of the edited file
+func (eq *EditorQuestion) GetExtension() string {
+	return eq.extension
+}
+
+// Edit writes the current draft or default value into a temporary file,
+// opens it in the editor and returns its content without comment lines.
+// The editor is killed when ctx is done.
+func (eq *EditorQuestion) Edit(ctx context.Context, input io.Reader) (string, error) {
+	file, err := os.CreateTemp("", "question-*"+eq.extension)
+	if nil != err {
+		return "", err
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	content := eq.draft
+	if "" == content && nil != eq.GetDefault() {
+		content = fmt.Sprintf("%v", eq.GetDefault())
+	}
+	_, err = file.WriteString(eq.template(content))
+	if closeErr := file.Close(); nil == err {
+		err = closeErr
+	}
+	if nil != err {
+		return "", err
+	}
+
+	if err = runEditor(ctx, path, input); nil != err {
+		return "", err
+	}
+
+	edited, err := os.ReadFile(path)
+	if nil != err {
+		return "", err
+	}
+
+	eq.draft = eq.stripComments(string(edited))
+	return eq.draft, nil
+}
+
+// template returns the content of the edited file followed by the commented instructions
+func (eq *EditorQuestion) template(content string) string {
+	var sb strings.Builder
+
+	sb.WriteString(content)
+	if "" != content && !strings.HasSuffix(content, "\n") {
+		sb.WriteString("\n")
+	}
+	if "" == eq.commentPrefix {
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+	if "" != eq.instructions {
+		for _, line := range strings.Split(eq.instructions, "\n") {
+			sb.WriteString(fmt.Sprintf("%s %s\n", eq.commentPrefix, line))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%s Lines starting with \"%s\" will be ignored.\n", eq.commentPrefix, eq.commentPrefix))
+
+	return sb.String()
+}
+
+// stripComments removes comment lines and trailing empty lines from the edited content
+func (eq *EditorQuestion) stripComments(content string) string {
+	var lines []string
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if "" != eq.commentPrefix && strings.HasPrefix(strings.TrimLeft(line, " \t"), eq.commentPrefix) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// runEditor opens given file in the editor and waits for the editor to exit
+func runEditor(ctx context.Context, path string, input io.Reader) error {
+	args := strings.Fields(editorCommand())
+	cmd := exec.CommandContext(ctx, args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	if file, ok := input.(*os.File); ok {
+		cmd.Stdin = file
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); nil != err {
+		if nil != ctx.Err() {
+			return ctx.Err()
+		}
+		return fmt.Errorf(`The editor "%s" failed: %w`, args[0], err)
+	}
+	return nil
+}
+
+// editorCommand returns the command launching the editor of the user
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); "" != editor {
+			return editor
+		}
+	}
+
+	if "windows" == runtime.GOOS {
+		return "notepad"
+	}
+	return "vi"
+}
@@ -0,0 +1,157 @@
+package question
+
+import (
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeEditor creates an editor script copying the edited file into capture
+// and replacing its content with the given answers, one answer per run
+func fakeEditor(t *testing.T, answers ...string) (capture string) {
+	t.Helper()
+	if "windows" == runtime.GOOS {
+		t.Skip("the fake editor requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	capture = filepath.Join(dir, "capture.txt")
+	script := "#!/bin/sh\ncp \"$1\" \"" + capture + "\"\n"
+	for i, answer := range answers {
+		answerFile := filepath.Join(dir, "answer"+strconv.Itoa(i))
+		if err := os.WriteFile(answerFile, []byte(answer), 0o600); nil != err {
+			t.Fatal(err)
+		}
+		script += "if [ ! -e \"" + answerFile + ".used\" ]; then cp \"" + answerFile + "\" \"$1\"; touch \"" + answerFile + ".used\"; exit 0; fi\n"
+	}
+	script += "exit 1\n"
+
+	editor := filepath.Join(dir, "editor")
+	if err := os.WriteFile(editor, []byte(script), 0o700); nil != err {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+
+	return capture
+}
+
+func TestNewEditorQuestion(t *testing.T) {
+	c := qt.New(t)
+	q := NewEditorQuestion("Changelog entry")
+
+	c.Assert(q.GetCommentPrefix(), qt.Equals, "#")
+	c.Assert(q.GetExtension(), qt.Equals, ".txt")
+	c.Assert(q.GetInstructions(), qt.Equals, "")
+
+	q.SetCommentPrefix(";")
+	q.SetExtension(".yaml")
+	q.SetInstructions("Describe the change")
+	c.Assert(q.GetCommentPrefix(), qt.Equals, ";")
+	c.Assert(q.GetExtension(), qt.Equals, ".yaml")
+	c.Assert(q.GetInstructions(), qt.Equals, "Describe the change")
+}
+
+func TestEditorCommand(t *testing.T) {
+	c := qt.New(t)
+
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+	c.Assert(editorCommand(), qt.Equals, "code --wait")
+
+	t.Setenv("VISUAL", "")
+	c.Assert(editorCommand(), qt.Equals, "nano")
+
+	t.Setenv("EDITOR", "")
+	if "windows" == runtime.GOOS {
+		c.Assert(editorCommand(), qt.Equals, "notepad")
+	} else {
+		c.Assert(editorCommand(), qt.Equals, "vi")
+	}
+}
+
+func TestHelper_AskEditorQuestion(t *testing.T) {
+	c := qt.New(t)
+	capture := fakeEditor(t, "replicas: 3\n# a comment\n  # indented comment\nimage: nginx\n\n")
+	o, buffer := createOutput()
+	q := NewEditorQuestion("Deployment values")
+	q.SetDefault("replicas: 1")
+	q.SetInstructions("Edit the values\nof the deployment")
+	q.SetNormalizer(func(input string) interface{} {
+		return strings.ToUpper(input)
+	})
+
+	answer, err := NewHelper().Ask(strings.NewReader(""), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "REPLICAS: 3\nIMAGE: NGINX")
+	c.Assert(buffer.String(), qt.Equals, " Deployment values [replicas: 1]:\n")
+
+	template, err := os.ReadFile(capture)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(template), qt.Equals, strings.Join([]string{
+		"replicas: 1",
+		"",
+		"# Edit the values",
+		"# of the deployment",
+		`# Lines starting with "#" will be ignored.`,
+		"",
+	}, "\n"))
+}
+
+type releaseNotesQuestion struct {
+	*EditorQuestion
+}
+
+func TestHelper_AskEmbeddedEditorQuestion(t *testing.T) {
+	c := qt.New(t)
+	fakeEditor(t, "Fixed the login page")
+	o, buffer := createOutput()
+	q := &releaseNotesQuestion{EditorQuestion: NewEditorQuestion("Release notes")}
+	q.SetDefault("TODO")
+	q.SetAutoAccept(time.Millisecond)
+
+	// the editor is opened for types embedding EditorQuestion, and the default is not auto-accepted
+	answer, err := NewHelper().Ask(strings.NewReader("typed answer\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "Fixed the login page")
+	c.Assert(buffer.String(), qt.Equals, " Release notes [TODO]:\n")
+}
+
+func TestHelper_AskEditorQuestionRetriesWithDraft(t *testing.T) {
+	c := qt.New(t)
+	capture := fakeEditor(t, "invalid\n", "valid\n")
+	o, buffer := createOutput()
+	q := NewEditorQuestion("Release notes")
+	q.SetMaxAttempts(2)
+	q.SetValidator(func(input string) (interface{}, error) {
+		if "valid" != input {
+			return nil, errors.New("The notes are invalid")
+		}
+		return input, nil
+	})
+
+	answer, err := NewHelper().Ask(strings.NewReader(""), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "valid")
+	c.Assert(buffer.String(), qt.Contains, "The notes are invalid")
+
+	// the second run edits the previous answer
+	template, err := os.ReadFile(capture)
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(string(template), "invalid\n"), qt.IsTrue)
+}
+
+func TestHelper_AskEditorQuestionEditorFails(t *testing.T) {
+	c := qt.New(t)
+	fakeEditor(t)
+	o, _ := createOutput()
+
+	_, err := NewHelper().Ask(strings.NewReader(""), o, NewEditorQuestion("Notes"))
+	c.Assert(err, qt.ErrorMatches, `The editor ".*editor" failed: exit status 1`)
+}
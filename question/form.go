@@ -0,0 +1,311 @@
+package question
+
+import (
+	"fmt"
+	"github.com/kilip/go-console/formatter"
+	"github.com/kilip/go-console/output"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// FormAnswers holds the answers of a Form keyed by step name
+type FormAnswers map[string]interface{}
+
+// Decode copies the answers into the fields of the struct pointed by target.
+// A field receives the answer named by its "form" tag, or by its field name.
+// Answers are converted only between types of the same kind, like string and a named string type,
+// or between numbers of the same family when the value fits the field.
+func (fa FormAnswers) Decode(target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if reflect.Ptr != rv.Kind() || reflect.Struct != rv.Elem().Kind() {
+		return fmt.Errorf("The target must be a pointer to a struct, %T given", target)
+	}
+
+	rv = rv.Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		name := field.Tag.Get("form")
+		if "" == name {
+			name = field.Name
+		}
+
+		answer, ok := fa[name]
+		if !ok || nil == answer || !rv.Field(i).CanSet() {
+			continue
+		}
+
+		value, ok := convertAnswer(reflect.ValueOf(answer), field.Type)
+		if !ok {
+			return fmt.Errorf(`The answer "%s" of type %T can not be assigned to field %s`, name, answer, field.Name)
+		}
+		rv.Field(i).Set(value)
+	}
+
+	return nil
+}
+
+// convertAnswer converts the answer to the field type, it returns false when the conversion
+// would change the meaning of the answer, like an int converted to a string
+func convertAnswer(value reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if value.Type().AssignableTo(t) {
+		return value, true
+	}
+	if !value.Type().ConvertibleTo(t) {
+		return value, false
+	}
+
+	switch {
+	case value.Kind() == t.Kind() && !isNumberKind(t.Kind()):
+	case isIntKind(value.Kind()) && isIntKind(t.Kind()):
+		if !fitsInt(value, t) {
+			return value, false
+		}
+	case isFloatKind(value.Kind()) && isFloatKind(t.Kind()):
+		if reflect.New(t).Elem().OverflowFloat(value.Float()) {
+			return value, false
+		}
+	default:
+		return value, false
+	}
+
+	return value.Convert(t), true
+}
+
+// fitsInt returns whether the integer value fits into an integer of type t
+func fitsInt(value reflect.Value, t reflect.Type) bool {
+	field := reflect.New(t).Elem()
+
+	if isUintKind(value.Kind()) {
+		u := value.Uint()
+		if isUintKind(t.Kind()) {
+			return !field.OverflowUint(u)
+		}
+		return u <= math.MaxInt64 && !field.OverflowInt(int64(u))
+	}
+
+	i := value.Int()
+	if isUintKind(t.Kind()) {
+		return i >= 0 && !field.OverflowUint(uint64(i))
+	}
+	return !field.OverflowInt(i)
+}
+
+// isNumberKind returns whether the kind is an integer or a float kind
+func isNumberKind(kind reflect.Kind) bool {
+	return isIntKind(kind) || isFloatKind(kind) || reflect.Complex64 == kind || reflect.Complex128 == kind
+}
+
+// isIntKind returns whether the kind is a signed or unsigned integer kind
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Uintptr
+}
+
+// isUintKind returns whether the kind is an unsigned integer kind
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+// isFloatKind returns whether the kind is a float kind
+func isFloatKind(kind reflect.Kind) bool {
+	return reflect.Float32 == kind || reflect.Float64 == kind
+}
+
+// FormStep represents a named question of a Form
+type FormStep struct {
+	name        string
+	question    IQuestion
+	skip        func(answers FormAnswers) bool
+	defaultFunc func(answers FormAnswers) interface{}
+}
+
+// GetName returns the name of the step
+func (fs *FormStep) GetName() string {
+	return fs.name
+}
+
+// GetQuestion returns the question of the step
+func (fs *FormStep) GetQuestion() IQuestion {
+	return fs.question
+}
+
+// SkipIf sets a condition on previous answers, the step is skipped when it returns true.
+// The step is asked again when an earlier answer is edited in the review.
+func (fs *FormStep) SkipIf(skip func(answers FormAnswers) bool) *FormStep {
+	fs.skip = skip
+	return fs
+}
+
+// SetDefaultFunc sets a function computing the default answer from previous answers.
+// The step is asked again when an earlier answer is edited in the review.
+func (fs *FormStep) SetDefaultFunc(defaultFunc func(answers FormAnswers) interface{}) *FormStep {
+	fs.defaultFunc = defaultFunc
+	return fs
+}
+
+// Form runs an ordered list of named questions, then lets the user
+// review the answers and edit one of them before confirming.
+type Form struct {
+	steps  []*FormStep
+	review bool
+}
+
+// NewForm creates new Form object
+func NewForm() *Form {
+	return &Form{
+		review: true,
+	}
+}
+
+// Add adds a named question to the form.
+// Questions without name are named after the step, so they can be answered by an AnswerProvider.
+func (f *Form) Add(name string, q IQuestion) *FormStep {
+	if named, ok := q.(interface{ SetName(name string) }); ok && "" == q.GetName() {
+		named.SetName(name)
+	}
+
+	step := &FormStep{name: name, question: q}
+	f.steps = append(f.steps, step)
+
+	return step
+}
+
+// GetSteps returns the steps of the form in order
+func (f *Form) GetSteps() []*FormStep {
+	return f.steps
+}
+
+// SetReview sets whether the answers are reviewed before confirming the form
+func (f *Form) SetReview(review bool) {
+	f.review = review
+}
+
+// IsReview returns whether the answers are reviewed before confirming the form
+func (f *Form) IsReview() bool {
+	return f.review
+}
+
+// Run asks every question of the form and returns the answers.
+// The review screen is skipped when the helper is not interactive.
+func (f *Form) Run(h *Helper, input io.Reader, o output.IOutput) (FormAnswers, error) {
+	answers := FormAnswers{}
+
+	if err := f.fill(h, input, o, answers); nil != err {
+		return answers, err
+	}
+
+	for f.review && h.IsInteractive() {
+		f.writeReview(o, answers)
+		selected, err := h.Ask(input, o, f.reviewQuestion(answers))
+		if nil != err {
+			return answers, err
+		}
+		if "" == selected {
+			break
+		}
+
+		index := f.stepIndex(selected.(string))
+		if err = f.ask(h, input, o, f.steps[index], answers); nil != err {
+			return answers, err
+		}
+		f.invalidate(index, answers)
+		if err = f.fill(h, input, o, answers); nil != err {
+			return answers, err
+		}
+	}
+
+	return answers, nil
+}
+
+// fill asks every step not answered yet and removes answers of skipped steps
+func (f *Form) fill(h *Helper, input io.Reader, o output.IOutput, answers FormAnswers) error {
+	for _, step := range f.steps {
+		if nil != step.skip && step.skip(answers) {
+			delete(answers, step.name)
+			continue
+		}
+		if _, ok := answers[step.name]; ok {
+			continue
+		}
+		if err := f.ask(h, input, o, step, answers); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ask asks the question of given step and stores its answer
+func (f *Form) ask(h *Helper, input io.Reader, o output.IOutput, step *FormStep, answers FormAnswers) error {
+	if nil != step.defaultFunc {
+		if q, ok := step.question.(interface{ SetDefault(value interface{}) }); ok {
+			q.SetDefault(step.defaultFunc(answers))
+		}
+	}
+
+	answer, err := h.Ask(input, o, step.question)
+	if nil != err {
+		return err
+	}
+	answers[step.name] = answer
+
+	return nil
+}
+
+// stepIndex returns the index of the step with given name
+func (f *Form) stepIndex(name string) int {
+	for i, step := range f.steps {
+		if step.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// invalidate removes the answers of the steps following given index which depend on previous answers,
+// so they are asked again with the edited answers
+func (f *Form) invalidate(index int, answers FormAnswers) {
+	for _, step := range f.steps[index+1:] {
+		if nil != step.skip || nil != step.defaultFunc {
+			delete(answers, step.name)
+		}
+	}
+}
+
+// writeReview writes the answers of the form into the output
+func (f *Form) writeReview(o output.IOutput, answers FormAnswers) {
+	o.Writeln("")
+	o.Writeln(" <comment>Review your answers:</comment>")
+	for _, step := range f.steps {
+		answer, ok := answers[step.name]
+		if !ok {
+			continue
+		}
+		if step.question.IsHidden() {
+			answer = "******"
+		}
+		o.Writeln(fmt.Sprintf("  %s <info>%s</info>", step.question.GetQuestion(), formatter.Escape(fmt.Sprintf("%v", answer))))
+	}
+	o.Writeln("")
+}
+
+// reviewQuestion returns the question confirming the answers or selecting a step to edit
+func (f *Form) reviewQuestion(answers FormAnswers) *ChoiceQuestion {
+	choices := []Choice{{Key: "y", Label: "Confirm", Value: ""}}
+
+	for i, step := range f.steps {
+		if _, ok := answers[step.name]; ok {
+			choices = append(choices, Choice{
+				Key:   strconv.Itoa(i + 1),
+				Label: fmt.Sprintf("Edit: %s", step.question.GetQuestion()),
+				Value: step.name,
+			})
+		}
+	}
+
+	q := NewChoiceQuestion("Confirm the answers or select one to edit", choices)
+	q.SetDefault("y")
+
+	return q
+}
@@ -0,0 +1,194 @@
+package question
+
+import (
+	qt "github.com/frankban/quicktest"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func createForm() *Form {
+	f := NewForm()
+	f.Add("name", NewQuestion("Name?"))
+	f.Add("database", NewConfirmationQuestion("Use a database?", false))
+	port := NewIntQuestion("Database port?")
+	f.Add("port", port).
+		SkipIf(func(answers FormAnswers) bool {
+			return false == answers["database"]
+		}).
+		SetDefaultFunc(func(answers FormAnswers) interface{} {
+			return 5432
+		})
+
+	return f
+}
+
+func TestNewForm(t *testing.T) {
+	c := qt.New(t)
+	f := createForm()
+
+	c.Assert(f.IsReview(), qt.IsTrue)
+	f.SetReview(false)
+	c.Assert(f.IsReview(), qt.IsFalse)
+
+	c.Assert(f.GetSteps(), qt.HasLen, 3)
+	c.Assert(f.GetSteps()[0].GetName(), qt.Equals, "name")
+	c.Assert(f.GetSteps()[0].GetQuestion().GetName(), qt.Equals, "name")
+}
+
+func TestForm_Run(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	f := createForm()
+
+	answers, err := f.Run(NewHelper(), strings.NewReader("John\nyes\n\ny\n"), o)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answers, qt.DeepEquals, FormAnswers{"name": "John", "database": true, "port": 5432})
+	c.Assert(buffer.String(), qt.Contains, " Database port? [5432]:\n")
+	c.Assert(buffer.String(), qt.Contains, strings.Join([]string{
+		" Review your answers:",
+		"  Name? John",
+		"  Use a database? true",
+		"  Database port? 5432",
+		"",
+		" Confirm the answers or select one to edit [Confirm]:",
+		"  [y] Confirm",
+		"  [1] Edit: Name?",
+		"  [2] Edit: Use a database?",
+		"  [3] Edit: Database port?",
+	}, "\n"))
+}
+
+func TestForm_RunSkipsSteps(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	f := createForm()
+
+	answers, err := f.Run(NewHelper(), strings.NewReader("John\nno\n\n"), o)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answers, qt.DeepEquals, FormAnswers{"name": "John", "database": false})
+	c.Assert(buffer.String(), qt.Not(qt.Contains), "Database port?")
+}
+
+func TestForm_RunEditAnswer(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	f := createForm()
+
+	// edit the name, then enable the database which asks the skipped port
+	input := strings.NewReader("John\nno\n1\nJane\n2\nyes\n3306\ny\n")
+	answers, err := f.Run(NewHelper(), input, o)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answers, qt.DeepEquals, FormAnswers{"name": "Jane", "database": true, "port": 3306})
+
+	// disabling the database again removes the port
+	input = strings.NewReader("John\nyes\n\n2\nno\n\n")
+	answers, err = f.Run(NewHelper(), input, o)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answers, qt.DeepEquals, FormAnswers{"name": "John", "database": false})
+}
+
+func TestForm_RunEditAnswerAsksDependentSteps(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	f := NewForm()
+	f.Add("env", NewQuestion("Environment?"))
+	f.Add("owner", NewQuestion("Owner?"))
+	f.Add("url", NewQuestion("URL?")).
+		SetDefaultFunc(func(answers FormAnswers) interface{} {
+			return answers["env"].(string) + ".example.com"
+		})
+
+	// the url depending on the edited environment is asked again, the owner is kept
+	answers, err := f.Run(NewHelper(), strings.NewReader("dev\nJohn\n\n1\nprod\n\ny\n"), o)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answers, qt.DeepEquals, FormAnswers{"env": "prod", "owner": "John", "url": "prod.example.com"})
+	c.Assert(buffer.String(), qt.Contains, " URL? [prod.example.com]:\n")
+	c.Assert(strings.Count(buffer.String(), " Owner?:\n"), qt.Equals, 1)
+}
+
+func TestForm_RunEscapesReviewedAnswers(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	f := NewForm()
+	f.Add("name", NewQuestion("Name?"))
+
+	_, err := f.Run(NewHelper(), strings.NewReader("<error>John</error>\ny\n"), o)
+	c.Assert(err, qt.IsNil)
+	c.Assert(buffer.String(), qt.Contains, "  Name? <error>John</error>\n")
+}
+
+func TestForm_RunNonInteractive(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	f := NewForm()
+	q := NewQuestion("Name?")
+	q.SetDefault("John")
+	f.Add("name", q)
+	f.Add("database", NewConfirmationQuestion("Use a database?", true))
+	h := NewHelper()
+	h.SetInteractive(false)
+
+	answers, err := f.Run(h, strings.NewReader(""), o)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answers, qt.DeepEquals, FormAnswers{"name": "John", "database": true})
+	c.Assert(buffer.String(), qt.Equals, "")
+}
+
+func TestFormAnswers_Decode(t *testing.T) {
+	c := qt.New(t)
+	type config struct {
+		Name     string
+		Database bool  `form:"database"`
+		Port     int64 `form:"port"`
+		Ignored  string
+	}
+	var cfg config
+
+	err := FormAnswers{"Name": "John", "database": true, "port": 5432}.Decode(&cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg, qt.DeepEquals, config{Name: "John", Database: true, Port: 5432})
+
+	err = FormAnswers{"Name": []string{"John"}}.Decode(&cfg)
+	c.Assert(err, qt.ErrorMatches, `The answer "Name" of type \[\]string can not be assigned to field Name`)
+
+	err = FormAnswers{"Name": 8080}.Decode(&cfg)
+	c.Assert(err, qt.ErrorMatches, `The answer "Name" of type int can not be assigned to field Name`)
+
+	err = FormAnswers{"port": 5432.0}.Decode(&cfg)
+	c.Assert(err, qt.ErrorMatches, `The answer "port" of type float64 can not be assigned to field Port`)
+
+	err = FormAnswers{}.Decode(cfg)
+	c.Assert(err, qt.ErrorMatches, `The target must be a pointer to a struct, question.config given`)
+}
+
+func TestFormAnswers_DecodeConversions(t *testing.T) {
+	type env string
+	type cs struct {
+		Name   string
+		Answer interface{}
+		Target interface{}
+		Error  bool
+	}
+	cases := []cs{
+		{Name: "named string", Answer: "prod", Target: new(env)},
+		{Name: "int to int64", Answer: 8080, Target: new(int64)},
+		{Name: "int to uint16", Answer: 8080, Target: new(uint16)},
+		{Name: "float64 to float32", Answer: 1.5, Target: new(float32)},
+		{Name: "int overflows int8", Answer: 300, Target: new(int8), Error: true},
+		{Name: "negative int to uint", Answer: -1, Target: new(uint), Error: true},
+		{Name: "large uint64 to int64", Answer: uint64(math.MaxUint64), Target: new(int64), Error: true},
+		{Name: "int to string", Answer: 8080, Target: new(string), Error: true},
+		{Name: "int to float64", Answer: 8080, Target: new(float64), Error: true},
+		{Name: "string to bytes", Answer: "prod", Target: new([]byte), Error: true},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			_, ok := convertAnswer(reflect.ValueOf(testCase.Answer), reflect.TypeOf(testCase.Target).Elem())
+			c.Assert(ok, qt.Equals, !testCase.Error)
+		})
+	}
+}
@@ -1 +1,619 @@
 package question
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kilip/go-console/formatter"
+	"github.com/kilip/go-console/output"
+	"github.com/mattn/go-isatty"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrMissingInput is returned when the input stream is closed before an answer is given
+var ErrMissingInput = errors.New("Aborted.")
+
+// ErrInterrupted is returned when the user presses Ctrl+C while an answer is read from a terminal
+// in a hidden prompt, a choice menu or a prompt with suggestions. No signal is raised,
+// the caller decides whether the command stops.
+var ErrInterrupted = errors.New("Interrupted.")
+
+// ErrHiddenInput is returned when the answer of a hidden question can not be hidden
+// and the question does not fallback on visible input
+var ErrHiddenInput = errors.New("Unable to hide the response.")
+
+// ErrNoChoice is returned when the interactive list of a choice question has no enabled choice to select
+var ErrNoChoice = errors.New("There is no choice to select.")
+
+// MissingDefaultError is returned when a question without default answer
+// is asked in non-interactive mode
+type MissingDefaultError struct {
+	Question string
+	// Name is the stable name of the question, empty for unnamed questions
+	Name string
+}
+
+// Error returns the error message
+func (e *MissingDefaultError) Error() string {
+	if "" != e.Name {
+		return fmt.Sprintf(`The question "%s" (%s) has no default answer and can not be asked in non-interactive mode`, e.Question, e.Name)
+	}
+	return fmt.Sprintf(`The question "%s" has no default answer and can not be asked in non-interactive mode`, e.Question)
+}
+
+// IQuestion is implemented by Question and by every question type embedding it
+type IQuestion interface {
+	GetName() string
+	GetQuestion() string
+	GetDefault() interface{}
+	IsHidden() bool
+	IsHiddenFallback() bool
+	IsMultiline() bool
+	IsTrimmable() bool
+	GetAutoCompleterCallback() func(input string) []string
+	GetValidator() func(input string) (value interface{}, error error)
+	GetNormalizer() func(input string) interface{}
+	GetMaxAttempts() int
+	GetAutoAccept() time.Duration
+}
+
+// IConfirmedQuestion is implemented by questions asking the user to repeat a valid answer,
+// like SecretQuestion. The answer is accepted when both answers are equal.
+type IConfirmedQuestion interface {
+	IQuestion
+	GetConfirmation() IQuestion
+	GetMismatchMessage() string
+}
+
+// IEditorQuestion is implemented by questions answered in a text editor, like EditorQuestion.
+// The answer is returned by Edit instead of being read from input, and is never accepted automatically.
+type IEditorQuestion interface {
+	IQuestion
+	Edit(ctx context.Context, input io.Reader) (string, error)
+}
+
+// IPhraseQuestion is implemented by questions confirmed by typing a phrase,
+// like PhraseConfirmationQuestion. The phrase is written with the question.
+type IPhraseQuestion interface {
+	IQuestion
+	GetPhrase() string
+}
+
+// Helper asks questions to the user and reads their answers
+type Helper struct {
+	input       io.Reader
+	source      *contextReader
+	reader      *bufio.Reader
+	interactive bool
+	provider    AnswerProvider
+	recorder    AnswerRecorder
+	history     *History
+	// countdownInterval is the delay between two updates of the auto-accept countdown
+	countdownInterval time.Duration
+}
+
+// NewHelper creates new question Helper object
+func NewHelper() *Helper {
+	return &Helper{
+		interactive:       true,
+		countdownInterval: time.Second,
+	}
+}
+
+// SetInteractive sets whether questions are asked to the user.
+// In non-interactive mode every question resolves to its default answer.
+func (h *Helper) SetInteractive(interactive bool) {
+	h.interactive = interactive
+}
+
+// IsInteractive returns whether questions are asked to the user
+func (h *Helper) IsInteractive() bool {
+	return h.interactive
+}
+
+// SetAnswerProvider sets the provider of recorded answers.
+// Named questions with a provided answer are not asked,
+// the provided answer is validated as if it was typed by the user.
+func (h *Helper) SetAnswerProvider(provider AnswerProvider) {
+	h.provider = provider
+}
+
+// GetAnswerProvider returns the provider of recorded answers
+func (h *Helper) GetAnswerProvider() AnswerProvider {
+	return h.provider
+}
+
+// SetAnswerRecorder sets the recorder of given answers.
+// Answers of named questions are recorded, except for hidden questions.
+func (h *Helper) SetAnswerRecorder(recorder AnswerRecorder) {
+	h.recorder = recorder
+}
+
+// GetAnswerRecorder returns the recorder of given answers
+func (h *Helper) GetAnswerRecorder() AnswerRecorder {
+	return h.recorder
+}
+
+// SetHistory sets the history of the answers typed by the user.
+// Answers of named questions are added to the history, except for hidden questions.
+func (h *Helper) SetHistory(history *History) {
+	h.history = history
+}
+
+// GetHistory returns the history of the answers typed by the user
+func (h *Helper) GetHistory() *History {
+	return h.history
+}
+
+// Ask writes the question prompt into the output, reads the answer from input
+// and returns the normalized and validated value.
+// When the validator fails, the error is written into the output and the question
+// is asked again until GetMaxAttempts() is reached.
+// Answers given by the answer provider and, in non-interactive mode,
+// the default answer are returned without asking.
+func (h *Helper) Ask(input io.Reader, o output.IOutput, q IQuestion) (interface{}, error) {
+	return h.AskContext(context.Background(), input, o, q)
+}
+
+// AskContext asks the question like Ask, until ctx is done.
+// When ctx is cancelled or its deadline is exceeded, reading the answer is aborted,
+// the terminal state is restored and ctx.Err() is returned.
+// Reading is aborted without consuming input for inputs with a file descriptor, like os.Stdin, on Linux.
+// For other inputs and platforms, the read keeps running in background until input is received,
+// and that input is only returned to the next question asked by this Helper from the same input.
+// The default answer of a question with an auto-accept delay is returned
+// when the user does not start answering before the delay is over.
+func (h *Helper) AskContext(ctx context.Context, input io.Reader, o output.IOutput, q IQuestion) (interface{}, error) {
+	if answer, ok := h.providedAnswer(q); ok {
+		value, err := h.resolve(q, answer)
+		if nil == err {
+			h.record(q, answer)
+		}
+		return value, err
+	}
+
+	if !h.interactive {
+		return h.resolveDefault(q)
+	}
+
+	attempts := q.GetMaxAttempts()
+	autoAccept := h.autoAcceptDelay(q)
+	var err error
+
+	for i := 0; 0 == attempts || i < attempts; i++ {
+		var answer string
+		var value interface{}
+
+		answer, err = h.readAnswerContext(ctx, input, o, q, autoAccept)
+		if errors.Is(err, errAutoAccept) {
+			return h.resolveDefault(q)
+		}
+		if nil != err {
+			return nil, err
+		}
+		// once the user answered, the question waits for a valid answer
+		autoAccept = 0
+
+		value, err = h.resolve(q, answer)
+		if cq, ok := q.(IConfirmedQuestion); ok && nil == err && nil != cq.GetConfirmation() {
+			var confirmation string
+			if confirmation, err = h.readAnswerContext(ctx, input, o, cq.GetConfirmation(), 0); nil != err {
+				return nil, err
+			}
+			if confirmation != answer {
+				err = errors.New(cq.GetMismatchMessage())
+			}
+		}
+		if nil == err {
+			h.record(q, answer)
+			h.remember(q, answer)
+			return value, nil
+		}
+		h.writeError(o, err)
+	}
+
+	return nil, err
+}
+
+// providedAnswer returns the answer of a named question given by the answer provider
+func (h *Helper) providedAnswer(q IQuestion) (string, bool) {
+	if nil == h.provider || "" == q.GetName() {
+		return "", false
+	}
+	return h.provider.Answer(q.GetName())
+}
+
+// record records the answer of a named question, hidden answers are never recorded
+func (h *Helper) record(q IQuestion, answer string) {
+	if nil != h.recorder && "" != q.GetName() && !q.IsHidden() {
+		h.recorder.Record(q.GetName(), answer)
+	}
+}
+
+// remember adds the answer of a named question to the history, hidden answers are never added.
+// The history is a convenience, failing to write it does not fail the question.
+func (h *Helper) remember(q IQuestion, answer string) {
+	if nil != h.history && "" != q.GetName() && !q.IsHidden() {
+		_ = h.history.Add(q.GetName(), answer)
+	}
+}
+
+// historyEntries returns the earlier answers of a named question
+func (h *Helper) historyEntries(q IQuestion) []string {
+	if nil == h.history || "" == q.GetName() || q.IsHidden() {
+		return nil
+	}
+
+	entries, _ := h.history.Entries(q.GetName())
+	return entries
+}
+
+// resolveDefault resolves the default answer through the normalizer and validator,
+// as if the user had given an empty answer
+func (h *Helper) resolveDefault(q IQuestion) (interface{}, error) {
+	if nil == q.GetDefault() {
+		return nil, &MissingDefaultError{Question: q.GetQuestion(), Name: q.GetName()}
+	}
+
+	return h.resolve(q, "")
+}
+
+// autoAcceptDelay returns the delay after which the default answer of the question is accepted.
+// Answers typed in an editor are never accepted automatically.
+func (h *Helper) autoAcceptDelay(q IQuestion) time.Duration {
+	if _, ok := q.(IEditorQuestion); ok || nil == q.GetDefault() {
+		return 0
+	}
+	return q.GetAutoAccept()
+}
+
+// readAnswerContext reads a single answer from input until ctx is done
+// or, when autoAccept is not zero, until the auto-accept delay is over
+func (h *Helper) readAnswerContext(ctx context.Context, input io.Reader, o output.IOutput, q IQuestion, autoAccept time.Duration) (string, error) {
+	h.bufferedReader(input)
+	h.source.ctx = ctx
+	defer h.source.reset()
+
+	if autoAccept > 0 {
+		h.source.deadline = time.Now().Add(autoAccept)
+		if _, ok := terminalFd(input); ok {
+			h.source.tick = func(remaining time.Duration) {
+				h.writeCountdown(o, remaining)
+			}
+			if h.countdownInterval > 0 {
+				h.source.interval = h.countdownInterval
+			}
+		}
+	}
+
+	return h.readAnswer(ctx, input, o, q)
+}
+
+// readAnswer writes the question prompt and reads a single answer from input
+func (h *Helper) readAnswer(ctx context.Context, input io.Reader, o output.IOutput, q IQuestion) (string, error) {
+	// while the countdown is shown, answers are read as plain lines
+	// so interactive menus and suggestions do not overwrite the countdown
+	countdown := !h.source.deadline.IsZero()
+
+	if cq, ok := q.(*ChoiceQuestion); ok && !countdown {
+		if fd, ok := terminalFd(input); ok {
+			return h.readChoice(fd, input, o, cq)
+		}
+	}
+
+	if eq, ok := q.(IEditorQuestion); ok {
+		h.writeQuestion(o, q)
+		return eq.Edit(ctx, input)
+	}
+
+	h.writePrompt(o, q)
+	if q.IsHidden() {
+		answer, err := h.readHidden(input, o)
+		if !errors.Is(err, ErrHiddenInput) {
+			return answer, err
+		}
+		if !q.IsHiddenFallback() {
+			return "", err
+		}
+	}
+
+	var answer string
+	var err error
+
+	completer := q.GetAutoCompleterCallback()
+	history := h.historyEntries(q)
+	if (nil != completer || len(history) > 0) && !q.IsMultiline() && !countdown {
+		if fd, ok := terminalFd(input); ok {
+			return h.readAutocomplete(fd, input, o, completer, history)
+		}
+	}
+
+	if q.IsMultiline() {
+		answer, err = h.readMultiline(input)
+	} else {
+		answer, err = h.readLine(input)
+	}
+	if isInterrupted(err) {
+		// end the prompt line, the answer was not submitted
+		o.Writeln("")
+	}
+
+	return answer, err
+}
+
+// isInterrupted returns whether reading the answer was interrupted
+// by the context or by the auto-accept delay
+func isInterrupted(err error) bool {
+	return errors.Is(err, errAutoAccept) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// readChoice lets the user select choices from an interactive list and returns their keys
+func (h *Helper) readChoice(fd uintptr, input io.Reader, o output.IOutput, cq *ChoiceQuestion) (string, error) {
+	restore, err := enableCbreak(fd)
+	if nil != err {
+		h.writePrompt(o, cq)
+		return h.readLine(input)
+	}
+	defer restore()
+
+	h.writeQuestion(o, cq)
+	menu := newChoiceMenu(h.bufferedReader(input), o, cq)
+	if !menu.selectable() {
+		return "", ErrNoChoice
+	}
+	if cq.GetMultiSelect() {
+		return menu.selectMany()
+	}
+	return menu.selectOne()
+}
+
+// readMultiline reads the answer from input until the end of file is reached,
+// keeping embedded newlines
+func (h *Helper) readMultiline(input io.Reader) (string, error) {
+	var answer strings.Builder
+	reader := h.bufferedReader(input)
+
+	for {
+		line, err := reader.ReadString('\n')
+		answer.WriteString(line)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if nil != err {
+			return "", err
+		}
+	}
+
+	if _, ok := terminalFd(input); 0 == answer.Len() && !ok {
+		return "", ErrMissingInput
+	}
+
+	text := strings.TrimSuffix(answer.String(), "\n")
+	return strings.TrimSuffix(text, "\r"), nil
+}
+
+// readAutocomplete reads a single line from terminal input, suggesting answers
+// given by the autocompleter callback while the user types and recalling earlier answers
+func (h *Helper) readAutocomplete(fd uintptr, input io.Reader, o output.IOutput, completer func(input string) []string, history []string) (string, error) {
+	restore, err := enableCbreak(fd)
+	if nil != err {
+		return h.readLine(input)
+	}
+	defer restore()
+
+	return newLineReader(h.bufferedReader(input), o, completer, history).readLine()
+}
+
+// readHidden reads a single line from input with the terminal echo turned off
+func (h *Helper) readHidden(input io.Reader, o output.IOutput) (string, error) {
+	fd, ok := terminalFd(input)
+	if !ok {
+		return "", ErrHiddenInput
+	}
+
+	restore, err := enableCbreak(fd)
+	if nil != err {
+		return "", ErrHiddenInput
+	}
+	defer restore()
+
+	answer, err := readHiddenLine(h.bufferedReader(input))
+	o.Writeln("")
+
+	return answer, err
+}
+
+// readHiddenLine reads keys until enter is pressed and returns the typed text.
+// Keys are read one by one, so Ctrl+C interrupts the answer as soon as it is pressed.
+func readHiddenLine(reader *bufio.Reader) (string, error) {
+	var line []rune
+
+	for {
+		k, err := readKey(reader)
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			return string(line), nil
+		}
+		if errors.Is(err, io.EOF) {
+			return "", ErrMissingInput
+		}
+		if nil != err {
+			return "", err
+		}
+
+		switch k.code {
+		case keyEnter:
+			return string(line), nil
+		case keyInterrupt:
+			return "", ErrInterrupted
+		case keyEOF:
+			if 0 == len(line) {
+				return "", ErrMissingInput
+			}
+		case keyBackspace:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+		case keyRune:
+			line = append(line, k.char)
+		}
+	}
+}
+
+// resolve applies trimming, default value, normalizer and validator to the given answer.
+// The normalized value is passed to the validator in its string form.
+func (h *Helper) resolve(q IQuestion, answer string) (interface{}, error) {
+	normalizer := q.GetNormalizer()
+	validator := q.GetValidator()
+
+	if q.IsTrimmable() {
+		answer = strings.TrimSpace(answer)
+	}
+
+	if def := q.GetDefault(); "" == answer && nil != def {
+		if s, ok := def.(string); ok {
+			answer = s
+		} else if nil == normalizer {
+			if nil == validator {
+				return def, nil
+			}
+			answer = fmt.Sprintf("%v", def)
+		}
+	}
+
+	var value interface{} = answer
+	if nil != normalizer {
+		value = normalizer(answer)
+	}
+
+	if nil == validator {
+		return value, nil
+	}
+
+	if s, ok := value.(string); ok {
+		return validator(s)
+	}
+	return validator(fmt.Sprintf("%v", value))
+}
+
+// readLine reads a single line from input without its line ending
+func (h *Helper) readLine(input io.Reader) (string, error) {
+	line, err := h.bufferedReader(input).ReadString('\n')
+	if nil != err && (!errors.Is(err, io.EOF) || "" == line) {
+		if errors.Is(err, io.EOF) {
+			return "", ErrMissingInput
+		}
+		return "", err
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// terminalFd returns the file descriptor of input when it is a terminal
+func terminalFd(input io.Reader) (uintptr, bool) {
+	file, ok := input.(interface{ Fd() uintptr })
+	if !ok || !isatty.IsTerminal(file.Fd()) {
+		return 0, false
+	}
+
+	return file.Fd(), true
+}
+
+// bufferedReader returns a buffered reader for input.
+// The reader is kept between questions so buffered answers are not lost.
+func (h *Helper) bufferedReader(input io.Reader) *bufio.Reader {
+	if h.input != input || nil == h.reader {
+		h.input = input
+		h.source = newContextReader(input)
+		h.reader = bufio.NewReader(h.source)
+	}
+	return h.reader
+}
+
+// writePrompt writes the question, the available choices and the prompt into the output
+func (h *Helper) writePrompt(o output.IOutput, q IQuestion) {
+	prompt := " > "
+
+	h.writeQuestion(o, q)
+	if cq, ok := q.(*ChoiceQuestion); ok {
+		prompt = cq.GetPrompt()
+		for _, choice := range cq.GetChoices() {
+			o.Writeln(fmt.Sprintf("  [<comment>%s</comment>] %s", choice.Key, choice.describe()))
+		}
+	}
+	if nil != h.source && !h.source.deadline.IsZero() {
+		o.Writeln(countdownMessage(time.Until(h.source.deadline)))
+	}
+
+	o.Write(prompt)
+}
+
+// writeCountdown replaces the countdown written above the prompt line
+func (h *Helper) writeCountdown(o output.IOutput, remaining time.Duration) {
+	o.WriteO("\0337\033[1A\r\033[K", false, output.FormatRaw)
+	o.Write(countdownMessage(remaining))
+	o.WriteO("\0338", false, output.FormatRaw)
+}
+
+// countdownMessage returns the countdown showing the remaining seconds before the default answer is accepted
+func countdownMessage(remaining time.Duration) string {
+	seconds := int((remaining + time.Second - 1) / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+	return fmt.Sprintf(" <comment>The default answer is accepted in %ds</comment>", seconds)
+}
+
+// writeQuestion writes the question and its default value into the output
+func (h *Helper) writeQuestion(o output.IOutput, q IQuestion) {
+	text := q.GetQuestion()
+	def := q.GetDefault()
+
+	if q.IsMultiline() {
+		text = fmt.Sprintf("%s (press %s to finish)", text, eofShortcut())
+	}
+
+	switch tq := q.(type) {
+	case *ConfirmationQuestion:
+		yes, no := tq.labels()
+		text = fmt.Sprintf("%s (%s/%s)", text, yes, no)
+		if true == def {
+			def = yes
+		} else if false == def {
+			def = no
+		}
+	case *ChoiceQuestion:
+		if nil != def {
+			def = tq.defaultLabel(fmt.Sprintf("%v", def))
+		}
+	case IPhraseQuestion:
+		if phrase := tq.GetPhrase(); "" != phrase {
+			text = fmt.Sprintf("%s Type <question>%s</question> to confirm", text, formatter.Escape(phrase))
+		}
+	}
+
+	if nil == def || "" == def {
+		o.Writeln(fmt.Sprintf(" <info>%s</info>:", text))
+	} else {
+		o.Writeln(fmt.Sprintf(" <info>%s</info> [<comment>%v</comment>]:", text, def))
+	}
+}
+
+// eofShortcut returns the key combination ending multiline answers on current platform
+func eofShortcut() string {
+	if "windows" == runtime.GOOS {
+		return "Ctrl+Z then Enter"
+	}
+	return "Ctrl+D"
+}
+
+// writeError writes validation error into the output
+func (h *Helper) writeError(o output.IOutput, err error) {
+	if message := err.Error(); "" != message {
+		o.Writeln(fmt.Sprintf("<error>%s</error>", formatter.Escape(message)))
+	}
+}
@@ -0,0 +1,521 @@
+package question
+
+import (
+	"context"
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/sys/unix"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHelper_AskHiddenOnTerminal(t *testing.T) {
+	c := qt.New(t)
+	master, slave := openPty(t)
+	o, buffer := createOutput()
+	q := NewQuestion("Token?")
+	_ = q.SetHidden(true)
+	q.SetHiddenFallback(false)
+
+	c.Assert(getLflag(t, slave)&unix.ECHO, qt.Not(qt.Equals), uint32(0))
+	writeWhen(t, master, slave, func(lflag uint32) bool {
+		return 0 == lflag&unix.ECHO
+	}, "s3x\x7fcr3t\n")
+
+	answer, err := NewHelper().Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "s3cr3t")
+	c.Assert(buffer.String(), qt.Equals, " Token?:\n > \n")
+
+	// terminal echo must be restored
+	c.Assert(getLflag(t, slave)&unix.ECHO, qt.Not(qt.Equals), uint32(0))
+}
+
+func TestHelper_AskInterruptedOnTerminal(t *testing.T) {
+	type cs struct {
+		Name      string
+		Question  func() IQuestion
+		Condition func(lflag uint32) bool
+	}
+	cases := []cs{
+		{
+			Name: "hidden question",
+			Question: func() IQuestion {
+				q := NewQuestion("Token?")
+				_ = q.SetHidden(true)
+				q.SetHiddenFallback(false)
+				return q
+			},
+			Condition: func(lflag uint32) bool { return 0 == lflag&unix.ECHO },
+		},
+		{
+			Name: "choice menu",
+			Question: func() IQuestion {
+				return NewChoiceQuestion("Environment?", []string{"dev", "prod"})
+			},
+			Condition: func(lflag uint32) bool { return 0 == lflag&unix.ICANON },
+		},
+		{
+			Name: "question with suggestions",
+			Question: func() IQuestion {
+				q := NewQuestion("Namespace?")
+				_ = q.SetAutoCompleterValues([]string{"default", "kube-system"})
+				return q
+			},
+			Condition: func(lflag uint32) bool { return 0 == lflag&unix.ICANON },
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			master, slave := openPty(t)
+			o, _ := createOutput()
+			lflag := getLflag(t, slave)
+
+			// Ctrl+C is read as a key, so no signal reaches the process
+			writeWhen(t, master, slave, func(lflag uint32) bool {
+				return testCase.Condition(lflag) && 0 == lflag&unix.ISIG
+			}, "ab\x03")
+
+			_, err := NewHelper().Ask(slave, o, testCase.Question())
+			c.Assert(errors.Is(err, ErrInterrupted), qt.IsTrue, qt.Commentf("%v", err))
+			c.Assert(getLflag(t, slave), qt.Equals, lflag)
+		})
+	}
+}
+
+func TestHelper_AskAutocompleteOnTerminal(t *testing.T) {
+	type cs struct {
+		Name     string
+		Input    string
+		Expected string
+	}
+	cases := []cs{
+		{Name: "accept suggestion with tab", Input: "ku\t\n", Expected: "kube-system"},
+		{Name: "accept suggestion with right arrow", Input: "ku\033[C\n", Expected: "kube-system"},
+		{Name: "typed text without accepting", Input: "ku\n", Expected: "ku"},
+		{Name: "cycle suggestions down", Input: "ku\033[B\n", Expected: "kube-public"},
+		{Name: "cycle suggestions up", Input: "ku\033[A\n", Expected: "kube-public"},
+		{Name: "backspace", Input: "kub\x7f\x7fd\t\n", Expected: "kd-namespace"},
+		{Name: "ignore unknown keys", Input: "k\033[Dd\n", Expected: "kd"},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			master, slave := openPty(t)
+			o, _ := createOutput()
+			q := NewQuestion("Namespace?")
+			var typed []string
+			q.SetAutoCompleterCallback(func(input string) []string {
+				typed = append(typed, input)
+				return []string{"kube-system", "kube-public", "kd-namespace", "default"}
+			})
+
+			writeWhen(t, master, slave, func(lflag uint32) bool {
+				return 0 == lflag&unix.ICANON
+			}, testCase.Input)
+
+			answer, err := NewHelper().Ask(slave, o, q)
+			c.Assert(err, qt.IsNil)
+			c.Assert(answer, qt.Equals, testCase.Expected)
+			c.Assert(typed[0], qt.Equals, "")
+			c.Assert(typed[1], qt.Equals, "k")
+			c.Assert(getLflag(t, slave)&unix.ICANON, qt.Not(qt.Equals), uint32(0))
+		})
+	}
+}
+
+func TestHelper_AskAutocompleteRendersSuggestion(t *testing.T) {
+	c := qt.New(t)
+	master, slave := openPty(t)
+	o, buffer := createOutput()
+	o.SetDecorated(true)
+	q := NewQuestion("Namespace?")
+	_ = q.SetAutoCompleterValues([]string{"kube-system"})
+
+	writeWhen(t, master, slave, func(lflag uint32) bool {
+		return 0 == lflag&unix.ICANON
+	}, "kube\n")
+
+	_, err := NewHelper().Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(buffer.String(), qt.Contains, "\0338\033[Kkube\033[2m-system\033[22m\033[7D")
+}
+
+func TestHelper_AskMultilineOnTerminal(t *testing.T) {
+	c := qt.New(t)
+	master, slave := openPty(t)
+	o, _ := createOutput()
+	h := NewHelper()
+	q := NewQuestion("Commit message")
+	q.SetMultiline(true)
+	q.SetDefault("default message")
+
+	// Ctrl+D on an empty line ends the answer
+	_, _ = master.Write([]byte("first line\nsecond line\n\x04"))
+	answer, err := h.Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "first line\nsecond line")
+
+	_, _ = master.Write([]byte("\x04"))
+	answer, err = h.Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "default message")
+}
+
+func TestHelper_AskChoiceMenuOnTerminal(t *testing.T) {
+	type cs struct {
+		Name     string
+		Input    string
+		Default  interface{}
+		Expected string
+	}
+	cases := []cs{
+		{Name: "confirm first choice", Input: "\n", Expected: "dev"},
+		{Name: "default choice is preselected", Input: "\n", Default: "staging", Expected: "staging"},
+		{Name: "default choice key is preselected", Input: "\n", Default: "2", Expected: "prod"},
+		{Name: "move down with arrow", Input: "\033[B\n", Expected: "staging"},
+		{Name: "move up with arrow wraps around", Input: "\033[A\n", Expected: "prod"},
+		{Name: "move with j and k", Input: "jjk\n", Expected: "staging"},
+		{Name: "ignore other keys", Input: "x\033[C\n", Default: "prod", Expected: "prod"},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			master, slave := openPty(t)
+			o, buffer := createOutput()
+			q := NewChoiceQuestion("Environment?", []string{"dev", "staging", "prod"})
+			q.SetDefault(testCase.Default)
+
+			writeWhen(t, master, slave, func(lflag uint32) bool {
+				return 0 == lflag&unix.ICANON
+			}, testCase.Input)
+
+			answer, err := NewHelper().Ask(slave, o, q)
+			c.Assert(err, qt.IsNil)
+			c.Assert(answer, qt.Equals, testCase.Expected)
+			c.Assert(strings.HasSuffix(buffer.String(), "\033[3A\r\033[J > "+testCase.Expected+"\n"), qt.IsTrue)
+		})
+	}
+}
+
+func TestHelper_AskChoiceMenuWithMapChoices(t *testing.T) {
+	c := qt.New(t)
+	master, slave := openPty(t)
+	o, _ := createOutput()
+	q := NewChoiceQuestion("Environment?", map[string]interface{}{
+		"d": "Development",
+		"p": "Production",
+	})
+
+	writeWhen(t, master, slave, func(lflag uint32) bool {
+		return 0 == lflag&unix.ICANON
+	}, "j\n")
+
+	answer, err := NewHelper().Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "p")
+}
+
+func TestHelper_AskChoiceMenuWithNumericChoices(t *testing.T) {
+	type cs struct {
+		Name        string
+		MultiSelect bool
+		Default     interface{}
+		Input       string
+		Expected    interface{}
+	}
+	cases := []cs{
+		{Name: "select second choice", Input: "j\n", Expected: 2},
+		{Name: "select third choice", Input: "jj\n", Expected: 3},
+		{Name: "default key is preselected", Default: "1", Input: "\n", Expected: 2},
+		{Name: "check choices in multi select", MultiSelect: true, Input: "j j \n", Expected: []interface{}{2, 3}},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			master, slave := openPty(t)
+			o, _ := createOutput()
+			q := NewChoiceQuestion("Replicas?", []int{1, 2, 3})
+			q.SetMultiSelect(testCase.MultiSelect)
+			q.SetDefault(testCase.Default)
+
+			writeWhen(t, master, slave, func(lflag uint32) bool {
+				return 0 == lflag&unix.ICANON
+			}, testCase.Input)
+
+			answer, err := NewHelper().Ask(slave, o, q)
+			c.Assert(err, qt.IsNil)
+			c.Assert(answer, qt.DeepEquals, testCase.Expected)
+		})
+	}
+}
+
+func TestHelper_AskMultiSelectMenuOnTerminal(t *testing.T) {
+	type cs struct {
+		Name     string
+		Input    string
+		Default  interface{}
+		Min      int
+		Max      int
+		Expected []string
+		Message  string
+	}
+	cases := []cs{
+		{Name: "toggle with space", Input: " jj \n", Expected: []string{"dev", "prod"}},
+		{Name: "toggle twice", Input: "  j \n", Expected: []string{"staging"}},
+		{Name: "select all", Input: "a\n", Expected: []string{"dev", "staging", "prod"}},
+		{Name: "invert selection", Input: " i\n", Expected: []string{"staging", "prod"}},
+		{Name: "default choices are preselected", Input: "\n", Default: "0,2", Expected: []string{"dev", "prod"}},
+		{Name: "default list of choices is preselected", Input: "\n", Default: []string{"dev", "prod"}, Expected: []string{"dev", "prod"}},
+		{
+			Name:     "empty selection is rejected",
+			Input:    "\n \n",
+			Expected: []string{"dev"},
+			Message:  "Select at least 1 choice(s)",
+		},
+		{
+			Name:     "minimum selection",
+			Input:    " \n\033[B \n",
+			Min:      2,
+			Expected: []string{"dev", "staging"},
+			Message:  "Select at least 2 choice(s)",
+		},
+		{
+			Name:     "maximum selection",
+			Input:    "a\n \n",
+			Max:      2,
+			Expected: []string{"staging", "prod"},
+			Message:  "Select at most 2 choice(s)",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			master, slave := openPty(t)
+			o, buffer := createOutput()
+			q := NewChoiceQuestion("Environments?", []string{"dev", "staging", "prod"})
+			q.SetMultiSelect(true)
+			q.SetDefault(testCase.Default)
+			q.SetMinSelected(testCase.Min)
+			q.SetMaxSelected(testCase.Max)
+
+			writeWhen(t, master, slave, func(lflag uint32) bool {
+				return 0 == lflag&unix.ICANON
+			}, testCase.Input)
+
+			answer, err := NewHelper().Ask(slave, o, q)
+			c.Assert(err, qt.IsNil)
+			c.Assert(answer, qt.DeepEquals, testCase.Expected)
+			c.Assert(strings.HasSuffix(buffer.String(), " > "+strings.Join(testCase.Expected, ", ")+"\n"), qt.IsTrue)
+			if "" != testCase.Message {
+				c.Assert(buffer.String(), qt.Contains, " "+testCase.Message+"\n")
+			}
+		})
+	}
+}
+
+func TestHelper_AskChoiceMenuSkipsDisabledChoices(t *testing.T) {
+	c := qt.New(t)
+	master, slave := openPty(t)
+	o, _ := createOutput()
+	q := NewChoiceQuestion("Size?", []Choice{
+		{Key: "xs", Label: "Extra small", Value: 0, Disabled: true},
+		{Key: "s", Label: "Small", Value: 1},
+		{Key: "m", Label: "Medium", Value: 3, Disabled: true},
+		{Key: "l", Label: "Large", Value: 5},
+	})
+	q.SetMultiSelect(true)
+
+	writeWhen(t, master, slave, func(lflag uint32) bool {
+		return 0 == lflag&unix.ICANON
+	}, "a\n")
+
+	answer, err := NewHelper().Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.DeepEquals, []interface{}{1, 5})
+
+	q.SetMultiSelect(false)
+	writeWhen(t, master, slave, func(lflag uint32) bool {
+		return 0 == lflag&unix.ICANON
+	}, "j\n")
+
+	answer, err = NewHelper().Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, 5)
+}
+
+func TestHelper_AskChoiceMenuWithoutEnabledChoice(t *testing.T) {
+	type cs struct {
+		Name        string
+		Choices     []Choice
+		MultiSelect bool
+	}
+	cases := []cs{
+		{Name: "no choice", Choices: []Choice{}},
+		{Name: "no choice in multi select", Choices: []Choice{}, MultiSelect: true},
+		{
+			Name:    "every choice disabled",
+			Choices: []Choice{{Key: "s", Label: "Small", Disabled: true}, {Key: "l", Label: "Large", Disabled: true}},
+		},
+		{
+			Name:        "every choice disabled in multi select",
+			Choices:     []Choice{{Key: "s", Label: "Small", Disabled: true}, {Key: "l", Label: "Large", Disabled: true}},
+			MultiSelect: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			_, slave := openPty(t)
+			o, _ := createOutput()
+			q := NewChoiceQuestion("Size?", testCase.Choices)
+			q.SetMultiSelect(testCase.MultiSelect)
+
+			_, err := NewHelper().Ask(slave, o, q)
+			c.Assert(err, qt.Equals, ErrNoChoice)
+			c.Assert(getLflag(t, slave)&unix.ICANON, qt.Not(qt.Equals), uint32(0))
+		})
+	}
+}
+
+func TestHelper_AskContextRestoresTerminal(t *testing.T) {
+	c := qt.New(t)
+	_, slave := openPty(t)
+	o, _ := createOutput()
+	q := NewChoiceQuestion("Environment?", []string{"dev", "staging", "prod"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := NewHelper().AskContext(ctx, slave, o, q)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), qt.IsTrue)
+	c.Assert(getLflag(t, slave)&unix.ICANON, qt.Not(qt.Equals), uint32(0))
+	c.Assert(getLflag(t, slave)&unix.ECHO, qt.Not(qt.Equals), uint32(0))
+}
+
+func TestHelper_AskContextLeavesLaterInputOnTerminal(t *testing.T) {
+	type cs struct {
+		Name     string
+		Question func() IQuestion
+		Expected interface{}
+		Error    error
+	}
+	cases := []cs{
+		{
+			Name:     "cancelled question",
+			Question: func() IQuestion { return NewQuestion("Name?") },
+			Error:    context.DeadlineExceeded,
+		},
+		{
+			Name: "auto-accepted question",
+			Question: func() IQuestion {
+				q := NewQuestion("Name?")
+				q.SetDefault("John")
+				q.SetAutoAccept(20 * time.Millisecond)
+				return q
+			},
+			Expected: "John",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			master, slave := openPty(t)
+			o, _ := createOutput()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			answer, err := NewHelper().AskContext(ctx, slave, o, testCase.Question())
+			if nil != testCase.Error {
+				c.Assert(errors.Is(err, testCase.Error), qt.IsTrue)
+			} else {
+				c.Assert(err, qt.IsNil)
+				c.Assert(answer, qt.Equals, testCase.Expected)
+			}
+
+			// the aborted read must not consume the answer typed for the next question
+			_, _ = master.Write([]byte("Jane\n"))
+			next, cancelNext := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancelNext()
+			answer, err = NewHelper().AskContext(next, slave, o, NewQuestion("Next?"))
+			c.Assert(err, qt.IsNil)
+			c.Assert(answer, qt.Equals, "Jane")
+		})
+	}
+}
+
+func TestHelper_AskAutoAcceptShowsCountdownOnTerminal(t *testing.T) {
+	c := qt.New(t)
+	_, slave := openPty(t)
+	o, buffer := createOutput()
+	q := NewChoiceQuestion("Environment?", []string{"dev", "staging", "prod"})
+	q.SetDefault("staging")
+	q.SetAutoAccept(200 * time.Millisecond)
+	h := NewHelper()
+	h.countdownInterval = 20 * time.Millisecond
+
+	answer, err := h.Ask(slave, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "staging")
+	c.Assert(buffer.String(), qt.Contains, strings.Join([]string{
+		" Environment? [staging]:",
+		"  [0] dev",
+		"  [1] staging",
+		"  [2] prod",
+		" The default answer is accepted in 1s",
+		" > ",
+	}, "\n"))
+	// the countdown line above the prompt is updated in place
+	c.Assert(buffer.String(), qt.Contains, " > \0337\033[1A\r\033[K The default answer is accepted in 1s\0338")
+	c.Assert(strings.HasSuffix(buffer.String(), "\n"), qt.IsTrue)
+}
+
+func TestHelper_AskRecallsHistoryOnTerminal(t *testing.T) {
+	type cs struct {
+		Name     string
+		Input    string
+		Expected string
+	}
+	cases := []cs{
+		{Name: "recall last answer", Input: "\033[A\n", Expected: "staging"},
+		{Name: "recall earlier answer", Input: "\033[A\033[A\n", Expected: "prod"},
+		{Name: "stop at oldest answer", Input: "\033[A\033[A\033[A\033[A\n", Expected: "dev"},
+		{Name: "move back down", Input: "\033[A\033[A\033[B\n", Expected: "staging"},
+		{Name: "move past last answer", Input: "\033[A\033[B\n", Expected: ""},
+		{Name: "edited answer is no longer browsed", Input: "\033[A\x7f\x7f\x7f\033[A\n", Expected: "stag"},
+		{Name: "cycle suggestions of typed text", Input: "kube-\033[A\n", Expected: "kube-public"},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			master, slave := openPty(t)
+			o, _ := createOutput()
+			history := NewHistory(t.TempDir())
+			for _, answer := range []string{"dev", "prod", "staging"} {
+				c.Assert(history.Add("env", answer), qt.IsNil)
+			}
+			h := NewHelper()
+			h.SetHistory(history)
+			q := NewQuestion("Environment?")
+			q.SetName("env")
+			_ = q.SetAutoCompleterValues([]string{"kube-system", "kube-public"})
+
+			writeWhen(t, master, slave, func(lflag uint32) bool {
+				return 0 == lflag&unix.ICANON
+			}, testCase.Input)
+
+			answer, err := h.Ask(slave, o, q)
+			c.Assert(err, qt.IsNil)
+			c.Assert(answer, qt.Equals, testCase.Expected)
+		})
+	}
+}
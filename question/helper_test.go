@@ -0,0 +1,378 @@
+package question
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"github.com/kilip/go-console/formatter"
+	"github.com/kilip/go-console/output"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createOutput() (*output.Stream, *bytes.Buffer) {
+	buffer := new(bytes.Buffer)
+	o := output.NewStreamOutput(buffer, formatter.NewFormatter())
+	o.SetDecorated(false)
+
+	return o, buffer
+}
+
+func TestHelper_Ask(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	q := NewQuestion("What is your name?")
+	q.SetDefault("John")
+
+	answer, err := h.Ask(strings.NewReader("Jane\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "Jane")
+	c.Assert(buffer.String(), qt.Equals, " What is your name? [John]:\n > ")
+
+	answer, err = h.Ask(strings.NewReader("\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "John")
+}
+
+func TestHelper_AskKeepsBufferedAnswers(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	h := NewHelper()
+	input := strings.NewReader("first\nsecond")
+
+	answer, err := h.Ask(input, o, NewQuestion("First?"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "first")
+
+	answer, err = h.Ask(input, o, NewQuestion("Second?"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "second")
+
+	_, err = h.Ask(input, o, NewQuestion("Third?"))
+	c.Assert(errors.Is(err, ErrMissingInput), qt.IsTrue)
+}
+
+func TestHelper_AskTrimAndNormalize(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	q := NewQuestion("A question")
+	q.SetTrimmable(true)
+	q.SetNormalizer(func(input string) interface{} {
+		return strings.ToUpper(input)
+	})
+
+	answer, err := NewHelper().Ask(strings.NewReader("  foo  \n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "FOO")
+}
+
+func TestHelper_AskMaxAttempts(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := NewQuestion("A question")
+	q.SetMaxAttempts(2)
+	q.SetValidator(func(input string) (interface{}, error) {
+		if "valid" != input {
+			return nil, errors.New("invalid answer")
+		}
+		return input, nil
+	})
+
+	answer, err := NewHelper().Ask(strings.NewReader("foo\nvalid\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "valid")
+	c.Assert(strings.Count(buffer.String(), "invalid answer"), qt.Equals, 1)
+
+	buffer.Reset()
+	_, err = NewHelper().Ask(strings.NewReader("foo\nbar\nvalid\n"), o, q)
+	c.Assert(err, qt.ErrorMatches, "invalid answer")
+	c.Assert(strings.Count(buffer.String(), "invalid answer"), qt.Equals, 2)
+}
+
+func TestHelper_AskConfirmationQuestion(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	input := strings.NewReader("\nyes\n")
+	q := NewConfirmationQuestion("Continue?", false)
+
+	answer, err := h.Ask(input, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.IsFalse)
+	c.Assert(buffer.String(), qt.Equals, " Continue? (yes/no) [no]:\n > ")
+
+	answer, err = h.Ask(input, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.IsTrue)
+}
+
+func TestHelper_AskConfirmationQuestionRejectsUnrecognisedAnswers(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := NewConfirmationQuestion("Fortfahren?", false)
+	_ = q.SetLocales(LocaleGerman)
+
+	answer, err := NewHelper().Ask(strings.NewReader("yes\nja\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.IsTrue)
+	c.Assert(buffer.String(), qt.Equals, strings.Join([]string{
+		" Fortfahren? (ja/nein) [nein]:",
+		` > The answer "yes" is not recognised, please answer ja or nein`,
+		" Fortfahren? (ja/nein) [nein]:",
+		" > ",
+	}, "\n"))
+}
+
+func TestHelper_AskChoiceQuestion(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	input := strings.NewReader("1\n\n")
+	q := NewChoiceQuestion("Pick one", map[string]interface{}{
+		"foo": "Foo",
+		"bar": "Bar",
+	})
+	q.SetDefault("foo")
+
+	answer, err := h.Ask(input, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "foo")
+	c.Assert(buffer.String(), qt.Equals, strings.Join([]string{
+		" Pick one [Foo]:",
+		"  [bar] Bar",
+		"  [foo] Foo",
+		` > Value "1" is invalid`,
+		" Pick one [Foo]:",
+		"  [bar] Bar",
+		"  [foo] Foo",
+		" > ",
+	}, "\n"))
+}
+
+func TestHelper_AskHiddenFallback(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	q := NewQuestion("Token?")
+	_ = q.SetHidden(true)
+
+	answer, err := NewHelper().Ask(strings.NewReader("s3cr3t\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "s3cr3t")
+
+	q.SetHiddenFallback(false)
+	_, err = NewHelper().Ask(strings.NewReader("s3cr3t\n"), o, q)
+	c.Assert(errors.Is(err, ErrHiddenInput), qt.IsTrue)
+}
+
+func TestHelper_AskMultiline(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := NewQuestion("Release notes")
+	q.SetMultiline(true)
+	q.SetNormalizer(func(input string) interface{} {
+		return strings.ToUpper(input)
+	})
+	q.SetValidator(func(input string) (interface{}, error) {
+		if !strings.Contains(input, "\n") {
+			return nil, errors.New("expected multiple lines")
+		}
+		return input, nil
+	})
+
+	answer, err := NewHelper().Ask(strings.NewReader("first line\n\nthird line\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "FIRST LINE\n\nTHIRD LINE")
+	c.Assert(buffer.String(), qt.Matches, ` Release notes \(press Ctrl\+(D|Z then Enter) to finish\):\n > `)
+
+	_, err = NewHelper().Ask(strings.NewReader(""), o, q)
+	c.Assert(errors.Is(err, ErrMissingInput), qt.IsTrue)
+}
+
+func TestHelper_AskChoiceQuestionWithDescriptions(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := NewChoiceQuestion("Size?", []Choice{
+		{Key: "s", Label: "Small", Value: 1, Description: "a single replica"},
+		{Key: "l", Label: "Large", Value: 5, Disabled: true},
+	})
+
+	answer, err := NewHelper().Ask(strings.NewReader("s\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, 1)
+	c.Assert(buffer.String(), qt.Equals, strings.Join([]string{
+		" Size?:",
+		"  [s] Small - a single replica",
+		"  [l] Large (disabled)",
+		" > ",
+	}, "\n"))
+}
+
+func TestHelper_AskMultiSelectWithListDefault(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := NewChoiceQuestion("Environments?", []string{"dev", "staging", "prod"})
+	q.SetMultiSelect(true)
+	q.SetDefault([]string{"dev", "prod"})
+
+	c.Assert(q.GetDefault(), qt.Equals, "dev,prod")
+
+	answer, err := NewHelper().Ask(strings.NewReader("\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.DeepEquals, []string{"dev", "prod"})
+	c.Assert(strings.HasPrefix(buffer.String(), " Environments? [dev, prod]:\n"), qt.IsTrue)
+}
+
+func TestHelper_NonInteractive(t *testing.T) {
+	type cs struct {
+		Name     string
+		Question IQuestion
+		Expected interface{}
+	}
+	cq := NewChoiceQuestion("Environment?", []string{"dev", "prod"})
+	cq.SetDefault("1")
+	mq := NewChoiceQuestion("Environments?", []string{"dev", "staging", "prod"})
+	mq.SetMultiSelect(true)
+	mq.SetDefault("0,prod")
+	lq := NewChoiceQuestion("Environments?", []string{"dev", "staging", "prod"})
+	lq.SetMultiSelect(true)
+	lq.SetDefault([]string{"dev", "prod"})
+	iq := NewIntQuestion("Port?")
+	iq.SetDefault(8080)
+	q := NewQuestion("Name?")
+	q.SetDefault("John")
+
+	cases := []cs{
+		{Name: "question", Question: q, Expected: "John"},
+		{Name: "confirmation question", Question: NewConfirmationQuestion("Continue?", true), Expected: true},
+		{Name: "choice question by key", Question: cq, Expected: "prod"},
+		{Name: "multiselect choice question", Question: mq, Expected: []string{"dev", "prod"}},
+		{Name: "multiselect choice question with list default", Question: lq, Expected: []string{"dev", "prod"}},
+		{Name: "typed question", Question: iq, Expected: 8080},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			o, buffer := createOutput()
+			h := NewHelper()
+			h.SetInteractive(false)
+			c.Assert(h.IsInteractive(), qt.IsFalse)
+
+			answer, err := h.Ask(strings.NewReader("ignored\n"), o, testCase.Question)
+			c.Assert(err, qt.IsNil)
+			c.Assert(answer, qt.DeepEquals, testCase.Expected)
+			c.Assert(buffer.String(), qt.Equals, "")
+		})
+	}
+}
+
+func TestHelper_NonInteractiveWithoutDefault(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	h := NewHelper()
+	h.SetInteractive(false)
+
+	_, err := h.Ask(strings.NewReader(""), o, NewQuestion("Name?"))
+	var missing *MissingDefaultError
+	c.Assert(errors.As(err, &missing), qt.IsTrue)
+	c.Assert(missing.Question, qt.Equals, "Name?")
+	c.Assert(err, qt.ErrorMatches, `The question "Name\?" has no default answer and can not be asked in non-interactive mode`)
+
+	q := NewQuestion("Environment?")
+	q.SetName("deploy.env")
+	_, err = h.Ask(strings.NewReader(""), o, q)
+	c.Assert(errors.As(err, &missing), qt.IsTrue)
+	c.Assert(missing.Name, qt.Equals, "deploy.env")
+	c.Assert(err, qt.ErrorMatches, `The question "Environment\?" \(deploy.env\) has no default answer and can not be asked in non-interactive mode`)
+}
+
+func TestHelper_AskContextCancelled(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := h.AskContext(ctx, reader, o, NewQuestion("What is your name?"))
+	c.Assert(errors.Is(err, context.DeadlineExceeded), qt.IsTrue)
+	c.Assert(buffer.String(), qt.Equals, " What is your name?:\n > \n")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = h.AskContext(ctx, reader, o, NewQuestion("What is your name?"))
+	c.Assert(errors.Is(err, context.Canceled), qt.IsTrue)
+
+	// the answer typed after cancellation is given to the next question
+	go func() {
+		_, _ = writer.Write([]byte("Jane\n"))
+	}()
+	answer, err := h.Ask(reader, o, NewQuestion("What is your name?"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "Jane")
+}
+
+func TestHelper_AskAutoAccept(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	q := NewConfirmationQuestion("Continue?", true)
+	q.SetAutoAccept(20 * time.Millisecond)
+
+	answer, err := h.Ask(reader, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, true)
+	c.Assert(buffer.String(), qt.Equals, strings.Join([]string{
+		" Continue? (yes/no) [yes]:",
+		" The default answer is accepted in 1s",
+		" > ",
+		"",
+	}, "\n"))
+
+	// an answer given before the delay is over is used
+	q.SetAutoAccept(time.Minute)
+	go func() {
+		_, _ = writer.Write([]byte("no\n"))
+	}()
+	answer, err = h.Ask(reader, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, false)
+
+	// questions without default answer wait for the answer
+	nq := NewQuestion("What is your name?")
+	nq.SetAutoAccept(time.Millisecond)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = writer.Write([]byte("Jane\n"))
+	}()
+	answer, err = h.Ask(reader, o, nq)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "Jane")
+}
+
+func TestHelper_AskAutoAcceptAfterInvalidAnswer(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	q := NewIntQuestion("How many?")
+	q.SetDefault(3)
+	q.SetAutoAccept(20 * time.Millisecond)
+
+	go func() {
+		_, _ = writer.Write([]byte("many\n"))
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := h.AskContext(ctx, reader, o, q)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), qt.IsTrue)
+	c.Assert(strings.Count(buffer.String(), "The default answer is accepted"), qt.Equals, 1)
+}
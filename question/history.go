@@ -0,0 +1,113 @@
+package question
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// History stores the earlier answers of named questions, one JSON file per question name.
+// On a terminal, the answers are recalled with the up and down arrow keys.
+type History struct {
+	dir     string
+	maxSize int
+}
+
+// historyNameRegex matches characters not allowed in history file names
+var historyNameRegex = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// NewHistory creates new History object storing answers into given directory,
+// keeping the last 100 answers of every question
+func NewHistory(dir string) *History {
+	return &History{
+		dir:     dir,
+		maxSize: 100,
+	}
+}
+
+// DefaultHistoryDir returns the history directory of the application under the XDG state directory,
+// $XDG_STATE_HOME/<app>/history, falling back on ~/.local/state/<app>/history
+func DefaultHistoryDir(app string) (string, error) {
+	if state := os.Getenv("XDG_STATE_HOME"); "" != state {
+		return filepath.Join(state, app, "history"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if nil != err {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", app, "history"), nil
+}
+
+// GetDir returns the directory of the history files
+func (h *History) GetDir() string {
+	return h.dir
+}
+
+// SetMaxSize sets the maximum number of answers kept for every question
+func (h *History) SetMaxSize(size int) {
+	h.maxSize = size
+}
+
+// GetMaxSize returns the maximum number of answers kept for every question
+func (h *History) GetMaxSize() int {
+	return h.maxSize
+}
+
+// Path returns the path of the history file of the question with given name
+func (h *History) Path(name string) string {
+	return filepath.Join(h.dir, historyNameRegex.ReplaceAllString(name, "_")+".json")
+}
+
+// Entries returns the answers of the question with given name, oldest first
+func (h *History) Entries(name string) ([]string, error) {
+	contents, err := os.ReadFile(h.Path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if nil != err {
+		return nil, err
+	}
+
+	var entries []string
+	if err = json.Unmarshal(contents, &entries); nil != err {
+		return nil, fmt.Errorf(`The history file "%s" is invalid: %s`, h.Path(name), err)
+	}
+
+	return entries, nil
+}
+
+// Add appends an answer to the history of the question with given name.
+// Empty answers and answers equal to the last one are not added,
+// the oldest answers are removed when the maximum size is exceeded.
+func (h *History) Add(name string, answer string) error {
+	if "" == answer {
+		return nil
+	}
+
+	entries, err := h.Entries(name)
+	if nil != err {
+		return err
+	}
+	if len(entries) > 0 && entries[len(entries)-1] == answer {
+		return nil
+	}
+
+	entries = append(entries, answer)
+	if h.maxSize > 0 && len(entries) > h.maxSize {
+		entries = entries[len(entries)-h.maxSize:]
+	}
+
+	contents, err := json.MarshalIndent(entries, "", "  ")
+	if nil != err {
+		return err
+	}
+	if err = os.MkdirAll(h.dir, 0o700); nil != err {
+		return err
+	}
+
+	return os.WriteFile(h.Path(name), append(contents, '\n'), 0o600)
+}
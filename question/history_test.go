@@ -0,0 +1,92 @@
+package question
+
+import (
+	qt "github.com/frankban/quicktest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultHistoryDir(t *testing.T) {
+	c := qt.New(t)
+
+	t.Setenv("XDG_STATE_HOME", "/var/state")
+	dir, err := DefaultHistoryDir("deployer")
+	c.Assert(err, qt.IsNil)
+	c.Assert(dir, qt.Equals, filepath.Join("/var/state", "deployer", "history"))
+
+	home := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	dir, err = DefaultHistoryDir("deployer")
+	c.Assert(err, qt.IsNil)
+	c.Assert(dir, qt.Equals, filepath.Join(home, ".local", "state", "deployer", "history"))
+}
+
+func TestHistory(t *testing.T) {
+	c := qt.New(t)
+	dir := filepath.Join(t.TempDir(), "history")
+	h := NewHistory(dir)
+	c.Assert(h.GetDir(), qt.Equals, dir)
+	c.Assert(h.GetMaxSize(), qt.Equals, 100)
+	c.Assert(h.Path("db.host/name"), qt.Equals, filepath.Join(dir, "db.host_name.json"))
+
+	entries, err := h.Entries("db.host")
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.IsNil)
+
+	h.SetMaxSize(3)
+	for _, answer := range []string{"a", "b", "b", "", "c", "d"} {
+		c.Assert(h.Add("db.host", answer), qt.IsNil)
+	}
+
+	entries, err = h.Entries("db.host")
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.DeepEquals, []string{"b", "c", "d"})
+
+	info, err := os.Stat(h.Path("db.host"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(info.Mode().Perm(), qt.Equals, os.FileMode(0o600))
+}
+
+func TestHistory_Invalid(t *testing.T) {
+	c := qt.New(t)
+	h := NewHistory(t.TempDir())
+	c.Assert(os.WriteFile(h.Path("name"), []byte(`{}`), 0o600), qt.IsNil)
+
+	_, err := h.Entries("name")
+	c.Assert(err, qt.ErrorMatches, `The history file ".*name.json" is invalid: .*`)
+}
+
+func TestHelper_AskAddsAnswersToHistory(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	history := NewHistory(t.TempDir())
+	h := NewHelper()
+	h.SetHistory(history)
+	c.Assert(h.GetHistory(), qt.Equals, history)
+
+	named := NewQuestion("Host?")
+	named.SetName("host")
+	hidden := NewQuestion("Password?")
+	hidden.SetName("password")
+	_ = hidden.SetHidden(true)
+	input := strings.NewReader("localhost\nexample.com\ns3cr3t\nunnamed\n")
+
+	for _, q := range []*Question{named, named, hidden, NewQuestion("Unnamed?")} {
+		_, err := h.Ask(input, o, q)
+		c.Assert(err, qt.IsNil)
+	}
+
+	entries, err := history.Entries("host")
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.DeepEquals, []string{"localhost", "example.com"})
+
+	_, err = os.Stat(history.Path("password"))
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+	files, err := os.ReadDir(history.GetDir())
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 1)
+}
@@ -0,0 +1,92 @@
+package question
+
+import (
+	"bufio"
+)
+
+// Keys recognized by the interactive readers
+const (
+	keyRune = iota
+	keyEnter
+	keyTab
+	keyBackspace
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEscape
+	keyEOF
+	keyInterrupt
+	keyUnknown
+)
+
+// key represents a single key press read from a terminal
+type key struct {
+	code int
+	char rune
+}
+
+// readKey reads a single key press, decoding ANSI escape sequences of arrow keys
+func readKey(reader *bufio.Reader) (key, error) {
+	r, _, err := reader.ReadRune()
+	if nil != err {
+		return key{}, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return key{code: keyEnter}, nil
+	case '\t':
+		return key{code: keyTab}, nil
+	case 0x7f, 0x08:
+		return key{code: keyBackspace}, nil
+	case 0x04:
+		return key{code: keyEOF}, nil
+	case 0x03:
+		return key{code: keyInterrupt}, nil
+	case 0x1b:
+		return readEscapeSequence(reader)
+	}
+
+	if r < 0x20 {
+		return key{code: keyUnknown, char: r}, nil
+	}
+	return key{code: keyRune, char: r}, nil
+}
+
+// readEscapeSequence decodes the key press following an escape character
+func readEscapeSequence(reader *bufio.Reader) (key, error) {
+	if 0 == reader.Buffered() {
+		return key{code: keyEscape}, nil
+	}
+
+	next, _, err := reader.ReadRune()
+	if nil != err {
+		return key{}, err
+	}
+	if '[' != next && 'O' != next {
+		return key{code: keyUnknown, char: next}, nil
+	}
+
+	// skip parameters of the control sequence, e.g. "\033[1;5A"
+	final, _, err := reader.ReadRune()
+	for nil == err && (final >= '0' && final <= '9' || ';' == final) {
+		final, _, err = reader.ReadRune()
+	}
+	if nil != err {
+		return key{}, err
+	}
+
+	switch final {
+	case 'A':
+		return key{code: keyUp}, nil
+	case 'B':
+		return key{code: keyDown}, nil
+	case 'C':
+		return key{code: keyRight}, nil
+	case 'D':
+		return key{code: keyLeft}, nil
+	}
+
+	return key{code: keyUnknown, char: final}, nil
+}
@@ -0,0 +1,194 @@
+package question
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/kilip/go-console/output"
+	"io"
+	"strings"
+)
+
+// lineReader reads a single line from a terminal in cbreak mode,
+// showing the best autocompleter suggestion inline after the typed text.
+// On an empty line, the up and down arrow keys browse the earlier answers.
+type lineReader struct {
+	reader    *bufio.Reader
+	output    output.IOutput
+	completer func(input string) []string
+	history   []string
+	recalled  int
+	line      []rune
+	matches   []string
+	offset    int
+	selected  bool
+}
+
+// newLineReader creates new lineReader object, history holds the earlier answers oldest first
+func newLineReader(reader *bufio.Reader, o output.IOutput, completer func(input string) []string, history []string) *lineReader {
+	return &lineReader{
+		reader:    reader,
+		output:    o,
+		completer: completer,
+		history:   history,
+		recalled:  len(history),
+	}
+}
+
+// readLine reads keys until enter is pressed and returns the answer
+func (lr *lineReader) readLine() (string, error) {
+	// save cursor position, so the line can be redrawn on every key press
+	lr.write("\0337")
+	lr.complete()
+	lr.render()
+
+	for {
+		k, err := readKey(lr.reader)
+		if nil != err {
+			return "", lr.finish(err)
+		}
+
+		switch k.code {
+		case keyEnter:
+			if lr.selected {
+				lr.accept()
+			}
+			lr.matches = nil
+			lr.render()
+			lr.write("\n")
+			return string(lr.line), nil
+		case keyEOF:
+			if 0 == len(lr.line) {
+				return "", lr.finish(ErrMissingInput)
+			}
+		case keyInterrupt:
+			return "", lr.finish(ErrInterrupted)
+		case keyTab, keyRight:
+			lr.accept()
+			lr.edited()
+		case keyUp:
+			if lr.browsing() {
+				lr.recall(-1)
+			} else {
+				lr.cycle(-1)
+			}
+		case keyDown:
+			if lr.browsing() {
+				lr.recall(1)
+			} else {
+				lr.cycle(1)
+			}
+		case keyBackspace:
+			if len(lr.line) > 0 {
+				lr.line = lr.line[:len(lr.line)-1]
+				lr.edited()
+			}
+		case keyRune:
+			lr.line = append(lr.line, k.char)
+			lr.edited()
+		}
+		lr.render()
+	}
+}
+
+// browsing returns whether the arrow keys browse the history,
+// which is the case on an empty line or while an earlier answer is shown
+func (lr *lineReader) browsing() bool {
+	return len(lr.history) > 0 && (0 == len(lr.line) || lr.recalled < len(lr.history))
+}
+
+// recall replaces the line with the earlier answer at given offset from the shown one,
+// moving past the last answer clears the line
+func (lr *lineReader) recall(offset int) {
+	lr.recalled += offset
+	if lr.recalled < 0 {
+		lr.recalled = 0
+	}
+	if lr.recalled >= len(lr.history) {
+		lr.recalled = len(lr.history)
+		lr.line = nil
+	} else {
+		lr.line = []rune(lr.history[lr.recalled])
+	}
+	lr.complete()
+}
+
+// edited refreshes the suggestions after the line is changed by the user,
+// an edited earlier answer is no longer browsed
+func (lr *lineReader) edited() {
+	lr.recalled = len(lr.history)
+	lr.complete()
+}
+
+// complete refreshes the suggestions matching the typed text
+func (lr *lineReader) complete() {
+	lr.matches = nil
+	lr.offset = 0
+	lr.selected = false
+
+	if nil == lr.completer {
+		return
+	}
+
+	typed := string(lr.line)
+	for _, suggestion := range lr.completer(typed) {
+		if suggestion != typed && strings.HasPrefix(suggestion, typed) {
+			lr.matches = append(lr.matches, suggestion)
+		}
+	}
+}
+
+// cycle moves the current suggestion by given offset
+func (lr *lineReader) cycle(offset int) {
+	if 0 == len(lr.matches) {
+		return
+	}
+
+	count := len(lr.matches)
+	lr.offset = (lr.offset + offset + count) % count
+	lr.selected = true
+}
+
+// accept replaces the typed text with the current suggestion
+func (lr *lineReader) accept() {
+	if 0 == len(lr.matches) {
+		return
+	}
+	lr.line = []rune(lr.matches[lr.offset])
+}
+
+// render redraws the typed text and the current suggestion
+func (lr *lineReader) render() {
+	typed := string(lr.line)
+	text := "\0338\033[K" + typed
+
+	if len(lr.matches) > 0 {
+		suggestion := lr.matches[lr.offset][len(typed):]
+		if lr.output.IsDecorated() {
+			text += "\033[2m" + suggestion + "\033[22m"
+		} else {
+			text += suggestion
+		}
+		text += fmt.Sprintf("\033[%dD", len([]rune(suggestion)))
+	}
+
+	lr.write(text)
+}
+
+// finish clears the suggestion and ends the line before returning given error
+func (lr *lineReader) finish(err error) error {
+	if errors.Is(err, io.EOF) {
+		err = ErrMissingInput
+	}
+
+	lr.matches = nil
+	lr.render()
+	lr.write("\n")
+
+	return err
+}
+
+// write writes given text into the output without formatting
+func (lr *lineReader) write(text string) {
+	lr.output.WriteO(text, false, output.FormatRaw)
+}
@@ -0,0 +1,71 @@
+package question
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PhraseConfirmationQuestion represents a confirmation of a destructive operation,
+// confirmed only when the user types the exact phrase, e.g. the name of the dropped resource.
+// The question is asked once, a wrong or empty answer fails the question.
+// The question has no default answer, so it always fails in non-interactive mode.
+type PhraseConfirmationQuestion struct {
+	phrase       string
+	ignoreCase   bool
+	errorMessage string
+	*Question
+}
+
+// NewPhraseConfirmationQuestion creates new PhraseConfirmationQuestion object confirmed by given phrase
+func NewPhraseConfirmationQuestion(question string, phrase string) *PhraseConfirmationQuestion {
+	q := &PhraseConfirmationQuestion{
+		phrase:       phrase,
+		errorMessage: `The answer does not match "%s", the operation is cancelled`,
+		Question:     NewQuestion(question),
+	}
+	q.SetTrimmable(true)
+	q.SetMaxAttempts(1)
+	q.SetValidator(q.getDefaultValidator())
+
+	return q
+}
+
+// GetDefault returns nil, the phrase must always be typed
+func (pq *PhraseConfirmationQuestion) GetDefault() interface{} {
+	return nil
+}
+
+// GetPhrase returns the phrase to type to confirm
+func (pq *PhraseConfirmationQuestion) GetPhrase() string {
+	return pq.phrase
+}
+
+// SetIgnoreCase sets whether the typed phrase is matched ignoring case
+func (pq *PhraseConfirmationQuestion) SetIgnoreCase(ignoreCase bool) {
+	pq.ignoreCase = ignoreCase
+}
+
+// IsIgnoreCase returns whether the typed phrase is matched ignoring case
+func (pq *PhraseConfirmationQuestion) IsIgnoreCase() bool {
+	return pq.ignoreCase
+}
+
+// SetErrorMessage sets the error message of a wrong answer, formatted with the phrase
+func (pq *PhraseConfirmationQuestion) SetErrorMessage(message string) {
+	pq.errorMessage = message
+}
+
+// GetErrorMessage returns the error message of a wrong answer
+func (pq *PhraseConfirmationQuestion) GetErrorMessage() string {
+	return pq.errorMessage
+}
+
+// getDefaultValidator returns true when the answer matches the phrase
+func (pq *PhraseConfirmationQuestion) getDefaultValidator() func(input string) (interface{}, error) {
+	return func(answer string) (interface{}, error) {
+		if "" != answer && (answer == pq.phrase || pq.ignoreCase && strings.EqualFold(answer, pq.phrase)) {
+			return true, nil
+		}
+		return nil, fmt.Errorf(pq.errorMessage, pq.phrase)
+	}
+}
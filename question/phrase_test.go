@@ -0,0 +1,123 @@
+package question
+
+import (
+	qt "github.com/frankban/quicktest"
+	"strings"
+	"testing"
+)
+
+func TestNewPhraseConfirmationQuestion(t *testing.T) {
+	c := qt.New(t)
+	q := NewPhraseConfirmationQuestion("Drop the database?", "production")
+
+	c.Assert(q.GetPhrase(), qt.Equals, "production")
+	c.Assert(q.IsIgnoreCase(), qt.IsFalse)
+	c.Assert(q.IsTrimmable(), qt.IsTrue)
+	c.Assert(q.GetMaxAttempts(), qt.Equals, 1)
+	c.Assert(q.GetErrorMessage(), qt.Equals, `The answer does not match "%s", the operation is cancelled`)
+}
+
+func TestPhraseConfirmationQuestion_Validator(t *testing.T) {
+	type cs struct {
+		Name       string
+		IgnoreCase bool
+		Answer     string
+		Valid      bool
+	}
+	cases := []cs{
+		{Name: "exact phrase", Answer: "production", Valid: true},
+		{Name: "different case", Answer: "Production", Valid: false},
+		{Name: "different case ignored", IgnoreCase: true, Answer: "PRODUCTION", Valid: true},
+		{Name: "yes", IgnoreCase: true, Answer: "y", Valid: false},
+		{Name: "empty", Answer: "", Valid: false},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			q := NewPhraseConfirmationQuestion("Drop the database?", "production")
+			q.SetIgnoreCase(testCase.IgnoreCase)
+
+			value, err := q.GetValidator()(testCase.Answer)
+			if testCase.Valid {
+				c.Assert(err, qt.IsNil)
+				c.Assert(value, qt.IsTrue)
+			} else {
+				c.Assert(err, qt.ErrorMatches, `The answer does not match "production", the operation is cancelled`)
+			}
+		})
+	}
+}
+
+func TestHelper_AskPhraseConfirmationQuestion(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	q := NewPhraseConfirmationQuestion("Drop the database?", "production")
+	input := strings.NewReader(" production \ny\nproduction\n")
+
+	answer, err := h.Ask(input, o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.IsTrue)
+	c.Assert(buffer.String(), qt.Equals, " Drop the database? Type production to confirm:\n > ")
+
+	// a wrong answer is not retried
+	buffer.Reset()
+	_, err = h.Ask(input, o, q)
+	c.Assert(err, qt.ErrorMatches, `The answer does not match "production", the operation is cancelled`)
+	c.Assert(strings.Count(buffer.String(), "Drop the database?"), qt.Equals, 1)
+
+	// there is no default answer to accept in non-interactive mode
+	h.SetInteractive(false)
+	_, err = h.Ask(input, o, q)
+	c.Assert(err, qt.ErrorMatches, `The question "Drop the database\?" has no default answer .*`)
+}
+
+type dropDatabaseQuestion struct {
+	*PhraseConfirmationQuestion
+}
+
+func TestHelper_AskEmbeddedPhraseConfirmationQuestion(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := &dropDatabaseQuestion{PhraseConfirmationQuestion: NewPhraseConfirmationQuestion("Drop the database?", "production")}
+
+	// the phrase is written for types embedding PhraseConfirmationQuestion
+	answer, err := NewHelper().Ask(strings.NewReader("production\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.IsTrue)
+	c.Assert(buffer.String(), qt.Equals, " Drop the database? Type production to confirm:\n > ")
+}
+
+func TestHelper_AskPhraseConfirmationQuestionIgnoresDefault(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	h := NewHelper()
+
+	// an empty phrase is never confirmed by an empty answer
+	q := NewPhraseConfirmationQuestion("Drop the database?", "")
+	_, err := h.Ask(strings.NewReader("\n"), o, q)
+	c.Assert(err, qt.ErrorMatches, `The answer does not match "", the operation is cancelled`)
+
+	// a default answer does not fill the empty answer
+	q = NewPhraseConfirmationQuestion("Drop the database?", "production")
+	q.SetDefault("production")
+	c.Assert(q.GetDefault(), qt.IsNil)
+	_, err = h.Ask(strings.NewReader("\n"), o, q)
+	c.Assert(err, qt.ErrorMatches, `The answer does not match "production", the operation is cancelled`)
+
+	h.SetInteractive(false)
+	_, err = h.Ask(strings.NewReader(""), o, q)
+	c.Assert(err, qt.ErrorMatches, `The question "Drop the database\?" has no default answer .*`)
+}
+
+func TestHelper_AskPhraseConfirmationQuestionHighlightsPhrase(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	o.SetDecorated(true)
+	q := NewPhraseConfirmationQuestion("Drop the database?", "<prod>")
+
+	_, err := NewHelper().Ask(strings.NewReader("<prod>\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(buffer.String(), qt.Contains, "\033[30;46m<prod>\033[39;49m")
+}
@@ -0,0 +1,71 @@
+package question
+
+import (
+	"fmt"
+	"golang.org/x/sys/unix"
+	"os"
+	"testing"
+	"time"
+)
+
+// openPty opens a pseudo-terminal pair, the slave side can be used as question input
+func openPty(t *testing.T) (master *os.File, slave *os.File) {
+	t.Helper()
+
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if nil != err {
+		t.Skipf("pseudo-terminal is not available: %s", err)
+	}
+
+	fd := int(master.Fd())
+	if err = unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); nil != err {
+		master.Close()
+		t.Skipf("can not unlock pseudo-terminal: %s", err)
+	}
+	n, err := unix.IoctlGetUint32(fd, unix.TIOCGPTN)
+	if nil != err {
+		master.Close()
+		t.Skipf("can not get pseudo-terminal number: %s", err)
+	}
+
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if nil != err {
+		master.Close()
+		t.Skipf("can not open pseudo-terminal slave: %s", err)
+	}
+
+	t.Cleanup(func() {
+		slave.Close()
+		master.Close()
+	})
+
+	return master, slave
+}
+
+// getLflag returns local mode flags of the terminal
+func getLflag(t *testing.T, tty *os.File) uint32 {
+	t.Helper()
+
+	termios, err := unix.IoctlGetTermios(int(tty.Fd()), unix.TCGETS)
+	if nil != err {
+		t.Fatalf("can not read terminal attributes: %s", err)
+	}
+	return termios.Lflag
+}
+
+// writeWhen writes input into master once the slave terminal local flags satisfy the condition
+func writeWhen(t *testing.T, master *os.File, slave *os.File, condition func(lflag uint32) bool, input string) {
+	t.Helper()
+
+	go func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			termios, err := unix.IoctlGetTermios(int(slave.Fd()), unix.TCGETS)
+			if nil == err && condition(termios.Lflag) {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		_, _ = master.Write([]byte(input))
+	}()
+}
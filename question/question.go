@@ -3,10 +3,12 @@ package question
 import (
 	"errors"
 	"reflect"
+	"time"
 )
 
 // Question represents a Question
 type Question struct {
+	name                  string
 	question              string
 	attempts              int
 	hidden                bool
@@ -17,6 +19,7 @@ type Question struct {
 	trimmable             bool
 	multiline             bool
 	normalizer            func(input string) interface{}
+	autoAccept            time.Duration
 }
 
 // NewQuestion creates new Question object
@@ -35,6 +38,17 @@ func (q *Question) GetQuestion() string {
 	return q.question
 }
 
+// GetName returns the stable identifier of the question
+func (q *Question) GetName() string {
+	return q.name
+}
+
+// SetName sets the stable identifier of the question,
+// used to find recorded answers and the history of the question
+func (q *Question) SetName(name string) {
+	q.name = name
+}
+
 // GetDefault returns the default answer value
 func (q *Question) GetDefault() interface{} {
 	return q.defaultValue
@@ -163,3 +177,16 @@ func (q *Question) IsTrimmable() bool {
 func (q *Question) SetTrimmable(trimmable bool) {
 	q.trimmable = trimmable
 }
+
+// GetAutoAccept returns the delay after which the default answer is accepted,
+// zero means the question waits for an answer
+func (q *Question) GetAutoAccept() time.Duration {
+	return q.autoAccept
+}
+
+// SetAutoAccept sets the delay after which the default answer is accepted
+// when the user does not answer, a countdown is shown while waiting.
+// The delay is ignored for questions without default answer.
+func (q *Question) SetAutoAccept(delay time.Duration) {
+	q.autoAccept = delay
+}
@@ -6,6 +6,7 @@ package question
 import (
 	qt "github.com/frankban/quicktest"
 	"testing"
+	"time"
 )
 
 func createDefQuestion() *Question {
@@ -122,3 +123,21 @@ func TestQuestion_IsTrimmable(t *testing.T) {
 	q.SetTrimmable(true)
 	c.Assert(q.IsTrimmable(), qt.IsTrue)
 }
+
+func TestQuestion_Name(t *testing.T) {
+	c := qt.New(t)
+	q := createDefQuestion()
+
+	c.Assert(q.GetName(), qt.Equals, "")
+	q.SetName("db.host")
+	c.Assert(q.GetName(), qt.Equals, "db.host")
+}
+
+func TestQuestion_AutoAccept(t *testing.T) {
+	c := qt.New(t)
+	q := NewQuestion("A question")
+
+	c.Assert(q.GetAutoAccept(), qt.Equals, time.Duration(0))
+	q.SetAutoAccept(10 * time.Second)
+	c.Assert(q.GetAutoAccept(), qt.Equals, 10*time.Second)
+}
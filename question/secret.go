@@ -0,0 +1,166 @@
+package question
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Character classes required by RequireCharacterClasses
+const (
+	ClassLower = iota
+	ClassUpper
+	ClassDigit
+	ClassSymbol
+)
+
+// CommonPasswords is a list of commonly used passwords, to be used with Denylist
+var CommonPasswords = []string{
+	"123456", "12345678", "123456789", "1234567890", "password", "password1",
+	"qwerty", "qwerty123", "abc123", "111111", "123123", "letmein", "welcome",
+	"admin", "iloveyou", "monkey", "dragon", "football", "sunshine", "secret",
+}
+
+// SecretRule checks the strength of a secret, returning an error when the secret is too weak
+type SecretRule func(secret string) error
+
+// SecretQuestion represents a hidden question asked twice,
+// the answer is accepted when both answers are equal and every strength rule passes.
+type SecretQuestion struct {
+	confirmationQuestion string
+	mismatchMessage      string
+	rules                []SecretRule
+	*Question
+}
+
+// NewSecretQuestion creates new SecretQuestion object
+func NewSecretQuestion(question string) *SecretQuestion {
+	q := &SecretQuestion{
+		confirmationQuestion: "Repeat to confirm",
+		mismatchMessage:      "The answers do not match",
+		Question:             NewQuestion(question),
+	}
+	_ = q.SetHidden(true)
+	q.SetValidator(q.getDefaultValidator())
+
+	return q
+}
+
+// SetConfirmationQuestion sets the question asked to repeat the secret
+func (sq *SecretQuestion) SetConfirmationQuestion(question string) {
+	sq.confirmationQuestion = question
+}
+
+// GetConfirmationQuestion returns the question asked to repeat the secret
+func (sq *SecretQuestion) GetConfirmationQuestion() string {
+	return sq.confirmationQuestion
+}
+
+// SetMismatchMessage sets the error message used when both answers differ
+func (sq *SecretQuestion) SetMismatchMessage(message string) {
+	sq.mismatchMessage = message
+}
+
+// GetMismatchMessage returns the error message used when both answers differ
+func (sq *SecretQuestion) GetMismatchMessage() string {
+	return sq.mismatchMessage
+}
+
+// AddRule adds a strength rule checked by the default validator
+func (sq *SecretQuestion) AddRule(rule SecretRule) {
+	sq.rules = append(sq.rules, rule)
+}
+
+// GetRules returns the strength rules checked by the default validator
+func (sq *SecretQuestion) GetRules() []SecretRule {
+	return sq.rules
+}
+
+// GetConfirmation returns the question asked to repeat the secret
+func (sq *SecretQuestion) GetConfirmation() IQuestion {
+	q := NewQuestion(sq.confirmationQuestion)
+	q.hidden = sq.IsHidden()
+	q.SetHiddenFallback(sq.IsHiddenFallback())
+
+	return q
+}
+
+// getDefaultValidator checks the secret against every rule and reports all failures
+func (sq *SecretQuestion) getDefaultValidator() func(input string) (interface{}, error) {
+	return func(secret string) (interface{}, error) {
+		var messages []string
+
+		for _, rule := range sq.rules {
+			if err := rule(secret); nil != err {
+				messages = append(messages, err.Error())
+			}
+		}
+
+		if len(messages) > 0 {
+			return nil, errors.New(strings.Join(messages, "\n"))
+		}
+		return secret, nil
+	}
+}
+
+// SecretMinLength requires the secret to be at least the given number of characters long
+func SecretMinLength(length int) SecretRule {
+	return func(secret string) error {
+		if len([]rune(secret)) < length {
+			return fmt.Errorf("The secret must be at least %d characters long", length)
+		}
+		return nil
+	}
+}
+
+// RequireCharacterClasses requires the secret to contain a character of every given class
+func RequireCharacterClasses(classes ...int) SecretRule {
+	names := map[int]string{
+		ClassLower:  "a lowercase letter",
+		ClassUpper:  "an uppercase letter",
+		ClassDigit:  "a digit",
+		ClassSymbol: "a symbol",
+	}
+
+	return func(secret string) error {
+		var missing []string
+
+		for _, class := range classes {
+			if !containsClass(secret, class) {
+				missing = append(missing, names[class])
+			}
+		}
+
+		if len(missing) > 0 {
+			return fmt.Errorf("The secret must contain %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
+
+// Denylist rejects secrets equal to one of the given words, ignoring case
+func Denylist(words ...string) SecretRule {
+	return func(secret string) error {
+		for _, word := range words {
+			if strings.EqualFold(secret, word) {
+				return errors.New("The secret is too common")
+			}
+		}
+		return nil
+	}
+}
+
+// containsClass returns whether the secret contains a character of given class
+func containsClass(secret string, class int) bool {
+	for _, r := range secret {
+		switch {
+		case ClassLower == class && unicode.IsLower(r),
+			ClassUpper == class && unicode.IsUpper(r),
+			ClassDigit == class && unicode.IsDigit(r),
+			ClassSymbol == class && (unicode.IsPunct(r) || unicode.IsSymbol(r) || ' ' == r):
+			return true
+		}
+	}
+	return false
+}
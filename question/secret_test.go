@@ -0,0 +1,120 @@
+package question
+
+import (
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"strings"
+	"testing"
+)
+
+func TestNewSecretQuestion(t *testing.T) {
+	c := qt.New(t)
+	q := NewSecretQuestion("Password?")
+
+	c.Assert(q.IsHidden(), qt.IsTrue)
+	c.Assert(q.GetConfirmationQuestion(), qt.Equals, "Repeat to confirm")
+	c.Assert(q.GetMismatchMessage(), qt.Equals, "The answers do not match")
+	c.Assert(q.GetRules(), qt.HasLen, 0)
+
+	q.SetConfirmationQuestion("Repeat the password")
+	q.SetMismatchMessage("Passwords differ")
+	c.Assert(q.GetConfirmationQuestion(), qt.Equals, "Repeat the password")
+	c.Assert(q.GetMismatchMessage(), qt.Equals, "Passwords differ")
+}
+
+func TestSecretQuestion_Rules(t *testing.T) {
+	type cs struct {
+		Name   string
+		Rule   SecretRule
+		Secret string
+		Error  string
+	}
+	cases := []cs{
+		{Name: "min length", Rule: SecretMinLength(8), Secret: "s3cr3t!!"},
+		{Name: "min length too short", Rule: SecretMinLength(8), Secret: "sécret", Error: "The secret must be at least 8 characters long"},
+		{Name: "character classes", Rule: RequireCharacterClasses(ClassLower, ClassUpper, ClassDigit, ClassSymbol), Secret: "Sécr3t!"},
+		{
+			Name:   "missing character classes",
+			Rule:   RequireCharacterClasses(ClassLower, ClassUpper, ClassDigit, ClassSymbol),
+			Secret: "secret",
+			Error:  "The secret must contain an uppercase letter, a digit, a symbol",
+		},
+		{Name: "denylist", Rule: Denylist(CommonPasswords...), Secret: "correct horse"},
+		{Name: "denylisted", Rule: Denylist(CommonPasswords...), Secret: "Password1", Error: "The secret is too common"},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			err := testCase.Rule(testCase.Secret)
+			if "" == testCase.Error {
+				c.Assert(err, qt.IsNil)
+				return
+			}
+			c.Assert(err, qt.ErrorMatches, testCase.Error)
+		})
+	}
+}
+
+func TestSecretQuestion_ValidatorReportsAllRules(t *testing.T) {
+	c := qt.New(t)
+	q := NewSecretQuestion("Password?")
+	q.AddRule(SecretMinLength(8))
+	q.AddRule(RequireCharacterClasses(ClassDigit))
+
+	_, err := q.GetValidator()("secret")
+	c.Assert(err, qt.ErrorMatches, "The secret must be at least 8 characters long\nThe secret must contain a digit")
+
+	value, err := q.GetValidator()("secret42")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "secret42")
+}
+
+func TestHelper_AskSecretQuestion(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := NewSecretQuestion("Password?")
+	q.AddRule(SecretMinLength(6))
+
+	answer, err := NewHelper().Ask(strings.NewReader("s3cr3t\ns3cr3t\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "s3cr3t")
+	c.Assert(buffer.String(), qt.Equals, " Password?:\n >  Repeat to confirm:\n > ")
+}
+
+func TestHelper_AskSecretQuestionAttempts(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	q := NewSecretQuestion("Password?")
+	q.AddRule(SecretMinLength(6))
+	q.SetMaxAttempts(3)
+
+	// a weak secret is not confirmed, a mismatch counts as a failed attempt
+	answer, err := NewHelper().Ask(strings.NewReader("weak\ns3cr3t\nsecret\ns3cr3t\ns3cr3t\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(answer, qt.Equals, "s3cr3t")
+	c.Assert(buffer.String(), qt.Contains, "The secret must be at least 6 characters long")
+	c.Assert(buffer.String(), qt.Contains, "The answers do not match")
+
+	q.SetMaxAttempts(2)
+	_, err = NewHelper().Ask(strings.NewReader("s3cr3t\nsecret\ns3cr3t\nfoobar\n"), o, q)
+	c.Assert(err, qt.ErrorMatches, "The answers do not match")
+
+	_, err = NewHelper().Ask(strings.NewReader("s3cr3t\n"), o, q)
+	c.Assert(errors.Is(err, ErrMissingInput), qt.IsTrue)
+}
+
+type deployKeyQuestion struct {
+	*SecretQuestion
+}
+
+func TestHelper_AskEmbeddedSecretQuestion(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	q := &deployKeyQuestion{SecretQuestion: NewSecretQuestion("Deploy key?")}
+	q.SetMaxAttempts(1)
+
+	// the confirmation is asked for types embedding SecretQuestion
+	_, err := NewHelper().Ask(strings.NewReader("s3cr3t\nsecret\n"), o, q)
+	c.Assert(err, qt.ErrorMatches, "The answers do not match")
+}
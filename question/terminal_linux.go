@@ -0,0 +1,52 @@
+//go:build linux
+// +build linux
+
+package question
+
+import (
+	"errors"
+	"golang.org/x/sys/unix"
+	"time"
+)
+
+// enableCbreak switches the terminal to unbuffered no-echo mode,
+// so key presses can be read as soon as they are typed.
+// Control keys like Ctrl+C are read as keys instead of raising signals.
+// The returned function restores the previous terminal state.
+func enableCbreak(fd uintptr) (func() error, error) {
+	return setTerminalMode(int(fd), func(termios *unix.Termios) {
+		termios.Lflag &^= unix.ECHO | unix.ICANON | unix.ISIG
+		termios.Cc[unix.VMIN] = 1
+		termios.Cc[unix.VTIME] = 0
+	})
+}
+
+// setTerminalMode applies given changes to the terminal attributes.
+// The returned function restores the previous terminal state.
+func setTerminalMode(fd int, update func(termios *unix.Termios)) (func() error, error) {
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if nil != err {
+		return nil, err
+	}
+
+	previous := *termios
+	update(termios)
+	if err = unix.IoctlSetTermios(fd, unix.TCSETS, termios); nil != err {
+		return nil, err
+	}
+
+	return func() error {
+		return unix.IoctlSetTermios(fd, unix.TCSETS, &previous)
+	}, nil
+}
+
+// waitReadable waits until fd has data to read, or at most the given timeout.
+// It returns false when the timeout is over, the fd is not read.
+func waitReadable(fd uintptr, timeout time.Duration) (bool, error) {
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	n, err := unix.Poll(fds, int(timeout/time.Millisecond))
+	if errors.Is(err, unix.EINTR) {
+		return false, nil
+	}
+	return n > 0, err
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+// +build !linux
+
+package question
+
+import (
+	"errors"
+	"time"
+)
+
+// errTerminalUnsupported is returned when terminal mode can not be changed on current platform
+var errTerminalUnsupported = errors.New("changing terminal mode is not supported on this platform")
+
+// enableCbreak is not supported on this platform
+func enableCbreak(fd uintptr) (func() error, error) {
+	return nil, errTerminalUnsupported
+}
+
+// waitReadable is not supported on this platform
+func waitReadable(fd uintptr, timeout time.Duration) (bool, error) {
+	return false, errTerminalUnsupported
+}
@@ -0,0 +1,196 @@
+package question
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kilip/go-console/output"
+	"io"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TypedQuestion represents a question whose answer is parsed into a value of type T
+type TypedQuestion[T any] struct {
+	parser         func(input string) (T, error)
+	typedValidator func(value T) error
+	*Question
+}
+
+// NewTypedQuestion creates new TypedQuestion object using given parser
+func NewTypedQuestion[T any](question string, parser func(input string) (T, error)) *TypedQuestion[T] {
+	q := &TypedQuestion[T]{
+		parser:   parser,
+		Question: NewQuestion(question),
+	}
+	q.SetTrimmable(true)
+	q.SetValidator(q.getDefaultValidator())
+
+	return q
+}
+
+// SetTypedValidator sets a validator called with the parsed answer
+func (tq *TypedQuestion[T]) SetTypedValidator(validator func(value T) error) {
+	tq.typedValidator = validator
+}
+
+// GetTypedValidator returns the validator called with the parsed answer
+func (tq *TypedQuestion[T]) GetTypedValidator() func(value T) error {
+	return tq.typedValidator
+}
+
+// getDefaultValidator parses the answer and validates the parsed value
+func (tq *TypedQuestion[T]) getDefaultValidator() func(input string) (interface{}, error) {
+	return func(input string) (interface{}, error) {
+		value, err := tq.parser(input)
+		if nil != err {
+			return nil, err
+		}
+
+		if nil != tq.typedValidator {
+			if err = tq.typedValidator(value); nil != err {
+				return nil, err
+			}
+		}
+
+		return value, nil
+	}
+}
+
+// AskTyped asks a typed question and returns its answer as a value of type T
+func AskTyped[T any](h *Helper, input io.Reader, o output.IOutput, q *TypedQuestion[T]) (T, error) {
+	var typed T
+
+	answer, err := h.Ask(input, o, q)
+	if nil != err {
+		return typed, err
+	}
+
+	typed, ok := answer.(T)
+	if !ok {
+		return typed, fmt.Errorf("The answer %v is not of type %T", answer, typed)
+	}
+
+	return typed, nil
+}
+
+// NewIntQuestion creates new question answered with an integer
+func NewIntQuestion(question string) *TypedQuestion[int] {
+	return NewTypedQuestion(question, ParseInt)
+}
+
+// NewFloatQuestion creates new question answered with a number
+func NewFloatQuestion(question string) *TypedQuestion[float64] {
+	return NewTypedQuestion(question, ParseFloat)
+}
+
+// NewBoolQuestion creates new question answered with yes or no
+func NewBoolQuestion(question string) *TypedQuestion[bool] {
+	return NewTypedQuestion(question, ParseBool)
+}
+
+// NewDurationQuestion creates new question answered with a duration like "1h30m"
+func NewDurationQuestion(question string) *TypedQuestion[time.Duration] {
+	return NewTypedQuestion(question, ParseDuration)
+}
+
+// NewURLQuestion creates new question answered with an absolute URL
+func NewURLQuestion(question string) *TypedQuestion[*url.URL] {
+	return NewTypedQuestion(question, ParseURL)
+}
+
+// NewEmailQuestion creates new question answered with an email address
+func NewEmailQuestion(question string) *TypedQuestion[string] {
+	return NewTypedQuestion(question, ParseEmail)
+}
+
+// NewPathQuestion creates new question answered with a file path,
+// the path is completed from the file system while typing on a terminal
+func NewPathQuestion(question string) *TypedQuestion[string] {
+	q := NewTypedQuestion(question, ParsePath)
+	q.SetAutoCompleterCallback(PathCompleter())
+
+	return q
+}
+
+// NewVersionQuestion creates new question answered with a semantic version
+func NewVersionQuestion(question string) *TypedQuestion[Version] {
+	return NewTypedQuestion(question, ParseVersion)
+}
+
+// ParseInt parses an integer answer
+func ParseInt(input string) (int, error) {
+	value, err := strconv.Atoi(input)
+	if nil != err {
+		return 0, fmt.Errorf(`"%s" is not a valid integer`, input)
+	}
+	return value, nil
+}
+
+// ParseFloat parses a number answer
+func ParseFloat(input string) (float64, error) {
+	value, err := strconv.ParseFloat(input, 64)
+	if nil != err {
+		return 0, fmt.Errorf(`"%s" is not a valid number`, input)
+	}
+	return value, nil
+}
+
+// ParseBool parses a yes or no answer
+func ParseBool(input string) (bool, error) {
+	switch strings.ToLower(input) {
+	case "y", "yes", "true", "1", "on":
+		return true, nil
+	case "n", "no", "false", "0", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf(`"%s" is not a valid answer, please answer yes or no`, input)
+}
+
+// ParseDuration parses a duration answer like "1h30m"
+func ParseDuration(input string) (time.Duration, error) {
+	value, err := time.ParseDuration(input)
+	if nil != err {
+		return 0, fmt.Errorf(`"%s" is not a valid duration, use a value like "1h30m" or "90s"`, input)
+	}
+	return value, nil
+}
+
+// ParseURL parses an absolute URL answer
+func ParseURL(input string) (*url.URL, error) {
+	value, err := url.Parse(input)
+	if nil != err || "" == value.Scheme || "" == value.Host {
+		return nil, fmt.Errorf(`"%s" is not a valid URL, use a value like "https://example.com"`, input)
+	}
+	return value, nil
+}
+
+// ParseEmail parses an email address answer, returning the address without display name
+func ParseEmail(input string) (string, error) {
+	value, err := mail.ParseAddress(input)
+	if nil != err {
+		return "", fmt.Errorf(`"%s" is not a valid email address`, input)
+	}
+	return value.Address, nil
+}
+
+// ParsePath parses a file path answer, expanding the leading "~" to the user home directory
+func ParsePath(input string) (string, error) {
+	if "" == input {
+		return "", errors.New("The path can not be empty")
+	}
+
+	if "~" == input || strings.HasPrefix(input, "~/") {
+		home, err := os.UserHomeDir()
+		if nil != err {
+			return "", fmt.Errorf(`"%s" can not be expanded: %s`, input, err)
+		}
+		input = home + input[1:]
+	}
+
+	return filepath.Clean(input), nil
+}
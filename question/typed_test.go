@@ -0,0 +1,131 @@
+package question
+
+import (
+	"errors"
+	qt "github.com/frankban/quicktest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wrap converts a typed parser into a parser returning interface{}
+func wrap[T any](parse func(input string) (T, error)) func(input string) (interface{}, error) {
+	return func(input string) (interface{}, error) {
+		return parse(input)
+	}
+}
+
+func TestTypedQuestion_Parsers(t *testing.T) {
+	type cs struct {
+		Name     string
+		Parse    func(input string) (interface{}, error)
+		Input    string
+		Expected interface{}
+		Error    string
+	}
+
+	cases := []cs{
+		{Name: "int", Parse: wrap(ParseInt), Input: "8080", Expected: 8080},
+		{Name: "invalid int", Parse: wrap(ParseInt), Input: "80a", Error: `"80a" is not a valid integer`},
+		{Name: "float", Parse: wrap(ParseFloat), Input: "0.75", Expected: 0.75},
+		{Name: "invalid float", Parse: wrap(ParseFloat), Input: "half", Error: `"half" is not a valid number`},
+		{Name: "bool yes", Parse: wrap(ParseBool), Input: "Yes", Expected: true},
+		{Name: "bool no", Parse: wrap(ParseBool), Input: "n", Expected: false},
+		{Name: "invalid bool", Parse: wrap(ParseBool), Input: "maybe", Error: `"maybe" is not a valid answer, please answer yes or no`},
+		{Name: "duration", Parse: wrap(ParseDuration), Input: "1h30m", Expected: 90 * time.Minute},
+		{Name: "invalid duration", Parse: wrap(ParseDuration), Input: "5 minutes", Error: `"5 minutes" is not a valid duration.*`},
+		{Name: "email", Parse: wrap(ParseEmail), Input: "John <john@example.com>", Expected: "john@example.com"},
+		{Name: "invalid email", Parse: wrap(ParseEmail), Input: "john@", Error: `"john@" is not a valid email address`},
+		{Name: "path", Parse: wrap(ParsePath), Input: "foo/../bar/", Expected: "bar"},
+		{Name: "empty path", Parse: wrap(ParsePath), Input: "", Error: `The path can not be empty`},
+		{Name: "version", Parse: wrap(ParseVersion), Input: "v1.2.3-rc.1+build.5", Expected: Version{1, 2, 3, "rc.1", "build.5"}},
+		{Name: "invalid version", Parse: wrap(ParseVersion), Input: "1.2", Error: `"1.2" is not a valid semantic version.*`},
+		{Name: "version out of range", Parse: wrap(ParseVersion), Input: "99999999999999999999.1.0", Error: `"99999999999999999999.1.0" is not a valid semantic version.*`},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			value, err := testCase.Parse(testCase.Input)
+			if "" != testCase.Error {
+				c.Assert(err, qt.ErrorMatches, testCase.Error)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(value, qt.DeepEquals, testCase.Expected)
+		})
+	}
+}
+
+func TestTypedQuestion_ParseURL(t *testing.T) {
+	c := qt.New(t)
+
+	value, err := ParseURL("https://example.com/path")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value.Host, qt.Equals, "example.com")
+
+	_, err = ParseURL("example.com")
+	c.Assert(err, qt.ErrorMatches, `"example.com" is not a valid URL.*`)
+}
+
+func TestTypedQuestion_ParsePathExpandsHome(t *testing.T) {
+	c := qt.New(t)
+	home, err := os.UserHomeDir()
+	if nil != err {
+		c.Skip("user home directory is not available")
+	}
+
+	value, err := ParsePath("~/config.yaml")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, filepath.Join(home, "config.yaml"))
+}
+
+func TestVersion_String(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(Version{Major: 1, Minor: 2, Patch: 3}.String(), qt.Equals, "1.2.3")
+	c.Assert(Version{1, 0, 0, "beta.1", "sha.5114f85"}.String(), qt.Equals, "1.0.0-beta.1+sha.5114f85")
+}
+
+func TestAskTyped(t *testing.T) {
+	c := qt.New(t)
+	o, buffer := createOutput()
+	h := NewHelper()
+	q := NewIntQuestion("Port?")
+	q.SetDefault(8080)
+	q.SetMaxAttempts(3)
+	q.SetTypedValidator(func(value int) error {
+		if value < 1024 {
+			return errors.New("The port must be greater than 1023")
+		}
+		return nil
+	})
+
+	var port int
+	port, err := AskTyped(h, strings.NewReader("http\n80\n 9000 \n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(port, qt.Equals, 9000)
+	c.Assert(buffer.String(), qt.Contains, `"http" is not a valid integer`)
+	c.Assert(buffer.String(), qt.Contains, "The port must be greater than 1023")
+
+	port, err = AskTyped(h, strings.NewReader("\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(port, qt.Equals, 8080)
+
+	_, err = AskTyped(h, strings.NewReader("a\nb\nc\n"), o, q)
+	c.Assert(err, qt.ErrorMatches, `"c" is not a valid integer`)
+}
+
+func TestAskTyped_URL(t *testing.T) {
+	c := qt.New(t)
+	o, _ := createOutput()
+	q := NewURLQuestion("Endpoint?")
+
+	var endpoint *url.URL
+	endpoint, err := AskTyped(NewHelper(), strings.NewReader("https://example.com\n"), o, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(endpoint.String(), qt.Equals, "https://example.com")
+}
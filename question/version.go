@@ -0,0 +1,65 @@
+package question
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionRegex matches semantic versions as defined by https://semver.org
+var versionRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// Version represents a semantic version
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+	Build      string
+}
+
+// ParseVersion parses a semantic version answer like "1.2.3" or "v1.2.3-rc.1"
+func ParseVersion(input string) (Version, error) {
+	invalid := fmt.Errorf(`"%s" is not a valid semantic version, use a value like "1.2.3"`, input)
+
+	matches := versionRegex.FindStringSubmatch(input)
+	if nil == matches {
+		return Version{}, invalid
+	}
+
+	var numbers [3]int
+	for i := range numbers {
+		number, err := strconv.Atoi(matches[i+1])
+		if nil != err {
+			// the number is out of range
+			return Version{}, invalid
+		}
+		numbers[i] = number
+	}
+
+	return Version{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		PreRelease: matches[4],
+		Build:      matches[5],
+	}, nil
+}
+
+// String returns the version in its canonical form
+func (v Version) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch))
+	if "" != v.PreRelease {
+		sb.WriteString("-" + v.PreRelease)
+	}
+	if "" != v.Build {
+		sb.WriteString("+" + v.Build)
+	}
+
+	return sb.String()
+}
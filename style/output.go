@@ -1,14 +1,17 @@
 package style
 
 import (
+	"context"
 	"github.com/kilip/go-console/output"
+	"github.com/kilip/go-console/question"
 	"io"
 	"strings"
 )
 
 // OutputStyle Decorates Output to add console style guide helpers.
 type OutputStyle struct {
-	input io.Reader
+	input          io.Reader
+	questionHelper *question.Helper
 	output.IOutput
 }
 
@@ -21,3 +24,79 @@ func (os *OutputStyle) NewLine() {
 func (os *OutputStyle) NewLineC(count int) {
 	os.Write(strings.Repeat("\n", count))
 }
+
+// Ask asks a question with given default value and validator.
+func (os *OutputStyle) Ask(q string, defaultValue string, validator func(input string) (interface{}, error)) (interface{}, error) {
+	qs := question.NewQuestion(q)
+	if "" != defaultValue {
+		qs.SetDefault(defaultValue)
+	}
+	qs.SetValidator(validator)
+
+	return os.AskQuestion(qs)
+}
+
+// Confirm asks for confirmation.
+func (os *OutputStyle) Confirm(q string, defaultValue bool) (bool, error) {
+	answer, err := os.AskQuestion(question.NewConfirmationQuestion(q, defaultValue))
+	if nil != err {
+		return false, err
+	}
+
+	return true == answer, nil
+}
+
+// Choice asks a choice question, unsupported choices are returned as an error.
+func (os *OutputStyle) Choice(q string, choices interface{}, defaultValue interface{}) (interface{}, error) {
+	cq := question.NewChoiceQuestion(q, nil)
+	if err := cq.SetChoices(choices); nil != err {
+		return nil, err
+	}
+	cq.SetDefault(defaultValue)
+
+	return os.AskQuestion(cq)
+}
+
+// SetInteractive sets whether questions are asked to the user,
+// in non-interactive mode every question resolves to its default answer.
+func (os *OutputStyle) SetInteractive(interactive bool) {
+	os.GetQuestionHelper().SetInteractive(interactive)
+}
+
+// IsInteractive returns whether questions are asked to the user.
+func (os *OutputStyle) IsInteractive() bool {
+	return os.GetQuestionHelper().IsInteractive()
+}
+
+// AskQuestion asks given question using the style input as reader.
+func (os *OutputStyle) AskQuestion(q question.IQuestion) (interface{}, error) {
+	helper := os.GetQuestionHelper()
+
+	answer, err := helper.Ask(os.input, os.IOutput, q)
+	if helper.IsInteractive() {
+		os.NewLine()
+	}
+
+	return answer, err
+}
+
+// AskQuestionContext asks given question like AskQuestion, until ctx is done.
+func (os *OutputStyle) AskQuestionContext(ctx context.Context, q question.IQuestion) (interface{}, error) {
+	helper := os.GetQuestionHelper()
+
+	answer, err := helper.AskContext(ctx, os.input, os.IOutput, q)
+	if helper.IsInteractive() {
+		os.NewLine()
+	}
+
+	return answer, err
+}
+
+// GetQuestionHelper returns the question helper used to ask questions,
+// creating it on first use.
+func (os *OutputStyle) GetQuestionHelper() *question.Helper {
+	if nil == os.questionHelper {
+		os.questionHelper = question.NewHelper()
+	}
+	return os.questionHelper
+}
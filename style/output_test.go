@@ -1,9 +1,12 @@
 package style
 
 import (
+	"context"
+	"errors"
 	qt "github.com/frankban/quicktest"
 	"github.com/kilip/go-console/formatter"
 	"github.com/kilip/go-console/output"
+	"github.com/kilip/go-console/question"
 	"strings"
 	"testing"
 )
@@ -49,3 +52,71 @@ func TestOutputStyle_NewLineC(t *testing.T) {
 	os.NewLineC(4)
 	ch.Assert(buff.Output, qt.Equals, strings.Repeat("\n", 5))
 }
+
+func TestOutputStyle_Ask(t *testing.T) {
+	ch := qt.New(t)
+	buff := NewReadWriterMock()
+	out := output.NewStreamOutput(buff, formatter.NewFormatter())
+	out.SetDecorated(false)
+	os := &OutputStyle{input: strings.NewReader("Jane\n\n"), IOutput: out}
+
+	answer, err := os.Ask("What is your name?", "John", nil)
+	ch.Assert(err, qt.IsNil)
+	ch.Assert(answer, qt.Equals, "Jane")
+	ch.Assert(buff.Output, qt.Equals, " What is your name? [John]:\n > \n")
+
+	confirmed, err := os.Confirm("Continue?", true)
+	ch.Assert(err, qt.IsNil)
+	ch.Assert(confirmed, qt.IsTrue)
+}
+
+func TestOutputStyle_Choice(t *testing.T) {
+	ch := qt.New(t)
+	buff := NewReadWriterMock()
+	out := output.NewStreamOutput(buff, formatter.NewFormatter())
+	out.SetDecorated(false)
+	os := &OutputStyle{input: strings.NewReader("1\n"), IOutput: out}
+
+	answer, err := os.Choice("Environment?", []string{"dev", "prod"}, nil)
+	ch.Assert(err, qt.IsNil)
+	ch.Assert(answer, qt.Equals, "prod")
+
+	buff.Reset()
+	answer, err = os.Choice("Environment?", "dev,prod", nil)
+	ch.Assert(err, qt.ErrorMatches, "Choices of type string are not supported")
+	ch.Assert(answer, qt.IsNil)
+	ch.Assert(buff.Output, qt.Equals, "")
+}
+
+func TestOutputStyle_NonInteractive(t *testing.T) {
+	ch := qt.New(t)
+	buff := NewReadWriterMock()
+	out := output.NewStreamOutput(buff, formatter.NewFormatter())
+	os := &OutputStyle{input: buff, IOutput: out}
+
+	ch.Assert(os.IsInteractive(), qt.IsTrue)
+	os.SetInteractive(false)
+	ch.Assert(os.IsInteractive(), qt.IsFalse)
+
+	answer, err := os.Ask("What is your name?", "John", nil)
+	ch.Assert(err, qt.IsNil)
+	ch.Assert(answer, qt.Equals, "John")
+	ch.Assert(buff.Output, qt.Equals, "")
+}
+
+func TestOutputStyle_AskQuestionContext(t *testing.T) {
+	ch := qt.New(t)
+	buff := NewReadWriterMock()
+	out := output.NewStreamOutput(buff, formatter.NewFormatter())
+	out.SetDecorated(false)
+	os := &OutputStyle{input: strings.NewReader("Jane\n"), IOutput: out}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := os.AskQuestionContext(ctx, question.NewQuestion("What is your name?"))
+	ch.Assert(errors.Is(err, context.Canceled), qt.IsTrue)
+
+	answer, err := os.AskQuestionContext(context.Background(), question.NewQuestion("What is your name?"))
+	ch.Assert(err, qt.IsNil)
+	ch.Assert(answer, qt.Equals, "Jane")
+}
@@ -0,0 +1,160 @@
+// Package validator provides validators for question.Question.SetValidator
+// and a Chain combinator to compose them.
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Validator validates an answer and returns its value, it matches question.Question.SetValidator
+type Validator func(input string) (interface{}, error)
+
+// Error messages of the validators, use WithMessage to replace the message of a validator
+const (
+	messageRequired  = "A value is required"
+	messageNotBlank  = "The value can not be blank"
+	messageMinLength = "The value must be at least %d characters long"
+	messageMaxLength = "The value must be at most %d characters long"
+	messageRegex     = "The value must match the pattern %s"
+	messageOneOf     = "The value must be one of %s"
+	messageInteger   = `"%s" is not a valid integer`
+	messageIntRange  = "The value must be between %d and %d"
+	messageFile      = `The file "%s" does not exist`
+	messageDir       = `The directory "%s" does not exist`
+)
+
+// Chain runs the validators in order and stops at the first failure.
+// Every validator receives the answer, the value of the last validator is returned.
+func Chain(validators ...Validator) Validator {
+	return func(input string) (interface{}, error) {
+		var value interface{} = input
+		var err error
+
+		for _, validator := range validators {
+			if value, err = validator(input); nil != err {
+				return nil, err
+			}
+		}
+
+		return value, nil
+	}
+}
+
+// WithMessage replaces the error message of the validator, e.g. to translate it
+func WithMessage(validator Validator, message string) Validator {
+	return func(input string) (interface{}, error) {
+		value, err := validator(input)
+		if nil != err {
+			return nil, errors.New(message)
+		}
+		return value, nil
+	}
+}
+
+// Required requires a non empty answer
+func Required() Validator {
+	return func(input string) (interface{}, error) {
+		if "" == input {
+			return nil, errors.New(messageRequired)
+		}
+		return input, nil
+	}
+}
+
+// NotBlank requires an answer containing other characters than white spaces
+func NotBlank() Validator {
+	return func(input string) (interface{}, error) {
+		if "" == strings.TrimSpace(input) {
+			return nil, errors.New(messageNotBlank)
+		}
+		return input, nil
+	}
+}
+
+// MinLength requires an answer of at least the given number of characters
+func MinLength(length int) Validator {
+	return func(input string) (interface{}, error) {
+		if len([]rune(input)) < length {
+			return nil, fmt.Errorf(messageMinLength, length)
+		}
+		return input, nil
+	}
+}
+
+// MaxLength requires an answer of at most the given number of characters
+func MaxLength(length int) Validator {
+	return func(input string) (interface{}, error) {
+		if len([]rune(input)) > length {
+			return nil, fmt.Errorf(messageMaxLength, length)
+		}
+		return input, nil
+	}
+}
+
+// Regex requires an answer matching the regular expression, it panics when the pattern is invalid
+func Regex(pattern string) Validator {
+	regex := regexp.MustCompile(pattern)
+
+	return func(input string) (interface{}, error) {
+		if !regex.MatchString(input) {
+			return nil, fmt.Errorf(messageRegex, pattern)
+		}
+		return input, nil
+	}
+}
+
+// OneOf requires an answer equal to one of the values
+func OneOf(values ...string) Validator {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+
+	return func(input string) (interface{}, error) {
+		for _, value := range values {
+			if input == value {
+				return input, nil
+			}
+		}
+		return nil, fmt.Errorf(messageOneOf, strings.Join(quoted, ", "))
+	}
+}
+
+// IntRange requires an integer answer between min and max inclusive, the integer is returned as value
+func IntRange(min int, max int) Validator {
+	return func(input string) (interface{}, error) {
+		value, err := strconv.Atoi(strings.TrimSpace(input))
+		if nil != err {
+			return nil, fmt.Errorf(messageInteger, input)
+		}
+		if value < min || value > max {
+			return nil, fmt.Errorf(messageIntRange, min, max)
+		}
+		return value, nil
+	}
+}
+
+// FileExists requires the path of an existing file
+func FileExists() Validator {
+	return func(input string) (interface{}, error) {
+		if info, err := os.Stat(input); nil != err || info.IsDir() {
+			return nil, fmt.Errorf(messageFile, input)
+		}
+		return input, nil
+	}
+}
+
+// IsDir requires the path of an existing directory
+func IsDir() Validator {
+	return func(input string) (interface{}, error) {
+		if info, err := os.Stat(input); nil != err || !info.IsDir() {
+			return nil, fmt.Errorf(messageDir, input)
+		}
+		return input, nil
+	}
+}
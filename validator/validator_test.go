@@ -0,0 +1,111 @@
+package validator
+
+import (
+	qt "github.com/frankban/quicktest"
+	"github.com/kilip/go-console/question"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(file, nil, 0o600); nil != err {
+		t.Fatal(err)
+	}
+
+	type cs struct {
+		Name      string
+		Validator Validator
+		Input     string
+		Expected  interface{}
+		Error     string
+	}
+	cases := []cs{
+		{Name: "required", Validator: Required(), Input: "foo", Expected: "foo"},
+		{Name: "required with empty answer", Validator: Required(), Input: "", Error: "A value is required"},
+		{Name: "required with spaces", Validator: Required(), Input: " ", Expected: " "},
+		{Name: "not blank", Validator: NotBlank(), Input: " foo ", Expected: " foo "},
+		{Name: "not blank with spaces", Validator: NotBlank(), Input: " \t", Error: "The value can not be blank"},
+		{Name: "min length", Validator: MinLength(3), Input: "äöü", Expected: "äöü"},
+		{Name: "min length too short", Validator: MinLength(3), Input: "ab", Error: "The value must be at least 3 characters long"},
+		{Name: "max length", Validator: MaxLength(3), Input: "abc", Expected: "abc"},
+		{Name: "max length too long", Validator: MaxLength(3), Input: "abcd", Error: "The value must be at most 3 characters long"},
+		{Name: "regex", Validator: Regex(`^[a-z]+$`), Input: "foo", Expected: "foo"},
+		{Name: "regex mismatch", Validator: Regex(`^[a-z]+$`), Input: "Foo", Error: `The value must match the pattern \^\[a-z\]\+\$`},
+		{Name: "one of", Validator: OneOf("dev", "prod"), Input: "prod", Expected: "prod"},
+		{Name: "one of mismatch", Validator: OneOf("dev", "prod"), Input: "test", Error: `The value must be one of "dev", "prod"`},
+		{Name: "int range", Validator: IntRange(1, 10), Input: "10", Expected: 10},
+		{Name: "int range below", Validator: IntRange(1, 10), Input: "0", Error: "The value must be between 1 and 10"},
+		{Name: "int range above", Validator: IntRange(1, 10), Input: "11", Error: "The value must be between 1 and 10"},
+		{Name: "int range not integer", Validator: IntRange(1, 10), Input: "1.5", Error: `"1.5" is not a valid integer`},
+		{Name: "file exists", Validator: FileExists(), Input: file, Expected: file},
+		{Name: "file is a directory", Validator: FileExists(), Input: dir, Error: `The file ".*" does not exist`},
+		{Name: "file is missing", Validator: FileExists(), Input: filepath.Join(dir, "missing"), Error: `The file ".*missing" does not exist`},
+		{Name: "is dir", Validator: IsDir(), Input: dir, Expected: dir},
+		{Name: "is dir with a file", Validator: IsDir(), Input: file, Error: `The directory ".*config.yaml" does not exist`},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			c := qt.New(t)
+			value, err := testCase.Validator(testCase.Input)
+			if "" != testCase.Error {
+				c.Assert(err, qt.ErrorMatches, testCase.Error)
+				c.Assert(value, qt.IsNil)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(value, qt.Equals, testCase.Expected)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	c := qt.New(t)
+	var called []string
+	track := func(name string, v Validator) Validator {
+		return func(input string) (interface{}, error) {
+			called = append(called, name)
+			return v(input)
+		}
+	}
+	validator := Chain(track("required", Required()), track("range", IntRange(1, 65535)), track("max", MaxLength(4)))
+
+	value, err := validator("8080")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "8080")
+	c.Assert(called, qt.DeepEquals, []string{"required", "range", "max"})
+
+	called = nil
+	_, err = validator("")
+	c.Assert(err, qt.ErrorMatches, "A value is required")
+	c.Assert(called, qt.DeepEquals, []string{"required"})
+
+	value, err = Chain()("foo")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "foo")
+}
+
+func TestWithMessage(t *testing.T) {
+	c := qt.New(t)
+	validator := WithMessage(IntRange(1, 65535), "Please enter a valid port")
+
+	_, err := validator("http")
+	c.Assert(err, qt.ErrorMatches, "Please enter a valid port")
+
+	value, err := validator("80")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, 80)
+}
+
+func TestValidator_SetValidator(t *testing.T) {
+	c := qt.New(t)
+	q := question.NewQuestion("Port?")
+	q.SetValidator(Chain(NotBlank(), IntRange(1, 65535)))
+
+	value, err := q.GetValidator()("443")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, 443)
+}